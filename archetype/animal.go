@@ -10,26 +10,37 @@ import (
 	"github.com/yohamta/donburi/features/transform"
 )
 
-var (
-	AnimalAnimationAction = &component.Animation{
-		Name:   "default",
-		Frames: []int{0, 1, 2, 3, 4, 5},
-	}
+// animalActions keeps one animation per species, animals of a species share it
+var animalActions = map[string][]*component.Animation{}
 
-	AnimalActions = []*component.Animation{AnimalAnimationAction}
-)
+func speciesActions(species *assets.AnimalSpecies) []*component.Animation {
+	actions, ok := animalActions[species.Name]
+	if !ok {
+		actions = []*component.Animation{{
+			Name:   "default",
+			Frames: species.Frames,
+		}}
+		animalActions[species.Name] = actions
+	}
+	return actions
+}
 
-func PlaceAnimalComponents(world donburi.World, space *cp.Space, debug *component.DebugData, animalPaths []assets.Path, mapWidth float64, mapHeight float64) {
-	for index, animalPath := range animalPaths {
-		animalShape := CreateBoxFromPath(space, animalPath, component.AnimalCollisionType)
+func PlaceAnimalComponents(world donburi.World, space *cp.Space, debug *component.DebugData, animalSpawns []assets.AnimalSpawn, mapWidth float64, mapHeight float64) {
+	for index, spawn := range animalSpawns {
+		species := assets.FindSpecies(spawn.Species, index)
+		animalShape := CreateKinematicBoxFromPath(space, spawn.Area, component.AnimalCollisionType)
 		box := animalShape.Class.(*cp.PolyShape)
 		vert := animalShape.Body().LocalToWorld(box.Vert(0))
 
 		newAnimal := &component.AnimalData{
+			Id:        index,
 			X:         vert.X,
 			Y:         vert.Y,
 			Shape:     animalShape,
 			Collected: false,
+			Home:      animalShape.Body().Position(),
+			Target:    animalShape.Body().Position(),
+			Species:   species,
 		}
 		debug.Shapes = append(debug.Shapes, newAnimal.Shape)
 		animal := world.Entry(
@@ -47,24 +58,48 @@ func PlaceAnimalComponents(world donburi.World, space *cp.Space, debug *componen
 		spriteData := component.SpriteData{}
 		spriteData.Layer = component.SpriteLayerDefault
 		spriteData.Pivot = component.SpritePivotTopLeft
-		if index == 0 || index%2 == 0 {
-			animationEntry := NewAnimationComponent(world, animal, animal, assets.TurtleSpriteSheet.Drawables(), 0.4)
-			animationData := component.AnimationComponent.Get(animationEntry)
-			animationData.AddAnimations(WasteActions)
-			animationData.SelectAnimationByName("default")
-			animationData.CurrentAnimation.Loop = true
+		animationEntry := NewAnimationComponent(world, animal, animal, species.SpriteSheet.Drawables(), species.Rate)
+		animationData := component.AnimationComponent.Get(animationEntry)
+		animationData.AddAnimations(speciesActions(species))
+		animationData.SelectAnimationByName("default")
+		animationData.CurrentAnimation.Loop = true
 
-			spriteData.Image = animationData.Cell()
-		} else {
-			animationEntry := NewAnimationComponent(world, animal, animal, assets.SealSpriteSheet.Drawables(), 0.4)
-			animationData := component.AnimationComponent.Get(animationEntry)
-			animationData.AddAnimations(WasteActions)
-			animationData.SelectAnimationByName("default")
-			animationData.CurrentAnimation.Loop = true
-
-			spriteData.Image = animationData.Cell()
-		}
+		spriteData.Image = animationData.Cell()
 		component.Sprite.SetValue(animal, spriteData)
 		transform.Transform.Get(animal).LocalPosition = math.Vec2{X: vert.X - 32, Y: vert.Y + 32}
 	}
 }
+
+// MoveAnimal centers the animal shape at the position and moves its sprite.
+func MoveAnimal(animal *component.AnimalData, center cp.Vector) {
+	MoveKinematicShape(animal.Shape, center)
+	animal.X, animal.Y = center.X+16, center.Y-16
+	if animal.Entry != nil {
+		transform.Transform.Get(animal.Entry).LocalPosition = math.Vec2{X: center.X - 16, Y: center.Y + 16}
+	}
+}
+
+// RescueAnimal marks the animal as rescued by a participant and gives it the
+// points. The host calls it on pickups, peers when the host reports them.
+func RescueAnimal(game *component.GameData, animal *component.AnimalData, participantID string) {
+	animal.Collected = true
+	animal.Entangled = false
+	animal.RescuedBy = participantID
+	if animal.Entry != nil {
+		component.Sprite.Get(animal.Entry).Hidden = true
+	}
+	participant := game.Session.RemoteClient.GameData.SessionParticipants[participantID]
+	if participant != nil {
+		participant.Score += game.Session.RemoteClient.GameData.Rules.AnimalPoints
+		if animal.Species != nil {
+			participant.Score += animal.Species.Points
+		}
+		participant.Round.Animals++
+	}
+	if game.Session.RemoteClient.Client.Id != nil && *game.Session.RemoteClient.Client.Id == participantID {
+		game.Match.Animals++
+		if !game.Muted {
+			PlayShipAudio()
+		}
+	}
+}
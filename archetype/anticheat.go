@@ -0,0 +1,149 @@
+package archetype
+
+import (
+	"amaru/component"
+	"log"
+	"math"
+	"time"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+)
+
+// NewAntiCheat adds the movement and pickup validation state, only the host
+// enables it since it is the one that keeps the authoritative scores.
+func NewAntiCheat(w donburi.World, islands []*cp.Shape, enabled bool) *donburi.Entry {
+	entry := w.Entry(w.Create(component.AntiCheat))
+	component.AntiCheat.SetValue(entry, component.AntiCheatData{
+		Enabled:    enabled,
+		Islands:    islands,
+		Moves:      make(map[string]*component.MovementRecord),
+		Violations: make(map[string]*component.Violations),
+	})
+	return entry
+}
+
+// ValidateMove checks a position and velocity reported by a remote player
+// against the max boat speed, the time since its last accepted position and
+// the islands. It returns the position and velocity that should be applied.
+func ValidateMove(w donburi.World, game *component.GameData, player *component.PlayerData, position cp.Vector, vector cp.Vector, spriteWidth float64) (cp.Vector, cp.Vector) {
+	anticheat := component.FindAntiCheat(w)
+	if anticheat == nil || !anticheat.Enabled {
+		return position, vector
+	}
+	// local boats move at (Speed + 5) sprite widths per second on each axis,
+	// scaled by their hull
+	maxAxis := (game.Speed + 5) * player.Hull.Speed
+	if player.HasPowerUp(component.PowerUpSpeed) {
+		maxAxis *= component.SpeedBoost
+	}
+	if math.Abs(vector.X) > maxAxis || math.Abs(vector.Y) > maxAxis {
+		vector = cp.Vector{
+			X: math.Max(-maxAxis, math.Min(vector.X, maxAxis)),
+			Y: math.Max(-maxAxis, math.Min(vector.Y, maxAxis)),
+		}
+		flagParticipant(anticheat, player, "reported speed above the max boat speed")
+	}
+
+	now := time.Now()
+	last := anticheat.Moves[player.ID]
+	if last == nil {
+		// first position of the round, nothing to compare with
+		anticheat.Moves[player.ID] = &component.MovementRecord{Position: position, Time: now}
+		return position, vector
+	}
+
+	if insideIsland(anticheat.Islands, position) || crossesIsland(anticheat.Islands, last.Position, position) {
+		flagParticipant(anticheat, player, "moved through an island")
+		last.Time = now
+		return last.Position, cp.Vector{}
+	}
+
+	// currents, hazards and bumps carry boats on top of their own speed
+	maxSpeed := maxAxis*spriteWidth*math.Sqrt2 + component.FindCurrents(w).MaxStrength() + component.FindHazards(w).MaxPush() + component.BumpMaxKnockback
+	maxDistance := maxSpeed*now.Sub(last.Time).Seconds()*component.MoveSpeedTolerance + component.MoveSlack
+	if distance := position.Distance(last.Position); distance > maxDistance {
+		position = last.Position.Add(position.Sub(last.Position).Normalize().Mult(maxDistance))
+		flagParticipant(anticheat, player, "moved faster than the max boat speed")
+	}
+	last.Position = position
+	last.Time = now
+	return position, vector
+}
+
+// ValidatePickup rejects pickups by remote players that are out of reach or
+// that happen right after an impossible move.
+func ValidatePickup(w donburi.World, player *component.PlayerData, target cp.BB) bool {
+	anticheat := component.FindAntiCheat(w)
+	if anticheat == nil || !anticheat.Enabled || player.Local {
+		return true
+	}
+	if violations := anticheat.Violations[player.ID]; violations != nil && time.Since(violations.Last) < component.PickupGrace {
+		return false
+	}
+	position := player.Body.Position()
+	if target.ClampVect(&position).Distance(position) > component.PickupReach {
+		flagParticipant(anticheat, player, "picked up something out of reach")
+		return false
+	}
+	return true
+}
+
+func insideIsland(islands []*cp.Shape, position cp.Vector) bool {
+	for _, island := range islands {
+		if island.PointQuery(position).Distance < -component.IslandDepth {
+			return true
+		}
+	}
+	return false
+}
+
+// NearIsland tells if an island is closer than the distance to the position,
+// it follows the outline of polygon and polyline coasts.
+func NearIsland(islands []*cp.Shape, position cp.Vector, distance float64) bool {
+	for _, island := range islands {
+		if island.PointQuery(position).Distance < distance {
+			return true
+		}
+	}
+	return false
+}
+
+func crossesIsland(islands []*cp.Shape, from cp.Vector, to cp.Vector) bool {
+	// polylines are too thin to be inside of, the move must not cross them
+	for _, island := range islands {
+		if _, ok := island.Class.(*cp.Segment); ok && island.SegmentQuery(from, to, 0, nil) {
+			return true
+		}
+	}
+	distance := from.Distance(to)
+	steps := int(distance / component.IslandDepth)
+	for i := 1; i < steps; i++ {
+		if insideIsland(islands, from.Lerp(to, float64(i)/float64(steps))) {
+			return true
+		}
+	}
+	return false
+}
+
+func flagParticipant(anticheat *component.AntiCheatData, player *component.PlayerData, reason string) {
+	now := time.Now()
+	violations := anticheat.Violations[player.ID]
+	if violations == nil {
+		violations = &component.Violations{}
+		anticheat.Violations[player.ID] = violations
+	}
+	if now.Sub(violations.Last) > component.StrikeWindow {
+		violations.Strikes = 0
+	}
+	violations.Strikes++
+	violations.Total++
+	violations.Last = now
+	if violations.Total == 1 {
+		log.Printf("anti-cheat: %s (%s) %s\n", player.Name, player.ID, reason)
+	}
+	if violations.Strikes >= component.SuspectStrikes && !violations.Reported {
+		violations.Reported = true
+		log.Printf("anti-cheat: %s (%s) is suspicious, %d violations, last: %s\n", player.Name, player.ID, violations.Total, reason)
+	}
+}
@@ -3,6 +3,8 @@ package archetype
 import (
 	"amaru/assets"
 	"amaru/component"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
 )
 
 func PlayAudioMenu() {
@@ -86,6 +88,16 @@ func PlayCollectedAudio() {
 	}
 }
 
+// ApplyVolumes sets the music and sound effect volumes from the profile.
+func ApplyVolumes(profile *component.Profile) {
+	for _, music := range []*audio.Player{assets.MenuAdioPlayer, assets.GameAudioPlayer, assets.WavesAudioPlayer} {
+		music.SetVolume(profile.Volumes.Music)
+	}
+	for _, effect := range []*audio.Player{assets.HeronAudioPlayer, assets.ShipAudioPlayer, assets.CollectedAudioPlayer, assets.ButtonClickPlayer} {
+		effect.SetVolume(profile.Volumes.Effects)
+	}
+}
+
 func PlayButtonClickAudio() {
 	assets.ButtonClickPlayer.Rewind()
 	assets.ButtonClickPlayer.Play()
@@ -0,0 +1,85 @@
+package archetype
+
+import (
+	"amaru/component"
+	"amaru/net"
+	"math"
+
+	"github.com/jakecoffman/cp"
+)
+
+// NewBump works out the knockback of two boats that ran into each other, the
+// one closing in faster is the aggressor. Only the host calls it.
+func NewBump(game *component.GameData, one *component.PlayerData, other *component.PlayerData) net.BumpMessage {
+	normal := other.Body.Position().Sub(one.Body.Position())
+	if normal.Length() < 1 {
+		normal = cp.Vector{X: 1}
+	}
+	normal = normal.Normalize()
+	oneSpeed := one.Body.Velocity().Dot(normal)
+	otherSpeed := -other.Body.Velocity().Dot(normal)
+	strength := math.Max(oneSpeed+otherSpeed, 0) * component.BumpKnockback
+	strength = math.Max(component.BumpMinKnockback, math.Min(strength, component.BumpMaxKnockback))
+
+	// slow contacts push both boats apart the same
+	oneImpulse, otherImpulse := normal.Mult(-strength/2), normal.Mult(strength/2)
+	var aggressor *component.PlayerData
+	if oneSpeed > otherSpeed && oneSpeed > component.BumpMinSpeed {
+		aggressor = one
+		oneImpulse, otherImpulse = normal.Mult(-strength*component.BumpRecoil), normal.Mult(strength)
+	} else if otherSpeed > oneSpeed && otherSpeed > component.BumpMinSpeed {
+		aggressor = other
+		oneImpulse, otherImpulse = normal.Mult(-strength), normal.Mult(strength*component.BumpRecoil)
+	}
+
+	bump := net.BumpMessage{
+		One:          one.ID,
+		Other:        other.ID,
+		OneImpulse:   net.Point{X: oneImpulse.X, Y: oneImpulse.Y},
+		OtherImpulse: net.Point{X: otherImpulse.X, Y: otherImpulse.Y},
+	}
+	if aggressor != nil {
+		bump.Aggressor = aggressor.ID
+		// teammates and shields keep the aggressor from losing points
+		bump.Penalty = !game.Session.RemoteClient.GameData.Teammates(one.ID, other.ID) && !aggressor.HasPowerUp(component.PowerUpShield)
+	}
+	return bump
+}
+
+// ApplyBump pushes the boats apart, stuns the one that was hit and takes the
+// points from the aggressor. Boats missing on this peer are skipped.
+func ApplyBump(game *component.GameData, one *component.PlayerData, other *component.PlayerData, bump net.BumpMessage) {
+	hits := []struct {
+		player  *component.PlayerData
+		impulse net.Point
+	}{
+		{one, bump.OneImpulse},
+		{other, bump.OtherImpulse},
+	}
+	local := false
+	for _, hit := range hits {
+		if hit.player == nil {
+			continue
+		}
+		player := hit.player
+		player.Knockback = cp.Vector{X: hit.impulse.X, Y: hit.impulse.Y}
+		player.BumpCooldown = component.BumpCooldownFrames
+		player.BumpFrames = component.BumpFlashFrames
+		if bump.Aggressor != "" && player.ID != bump.Aggressor && player.StunFrames < component.BumpStunFrames {
+			player.StunFrames = component.BumpStunFrames
+		}
+		if player.Local {
+			local = true
+		}
+		if bump.Penalty && player.ID == bump.Aggressor {
+			if participant := game.Session.RemoteClient.GameData.SessionParticipants[player.ID]; participant != nil {
+				participant.Score -= game.Session.RemoteClient.GameData.Rules.CollisionPoints
+				participant.Round.Collisions++
+			}
+			recordCollision(game, player)
+		}
+	}
+	if local && !game.Muted {
+		PlayShipAudio()
+	}
+}
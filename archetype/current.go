@@ -0,0 +1,66 @@
+package archetype
+
+import (
+	"amaru/assets"
+	"amaru/component"
+	"image/color"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/features/transform"
+)
+
+// NewCurrents adds the current zones of the level and an overlay sprite for
+// each of them, drawn over the sea background.
+func NewCurrents(world donburi.World, level *assets.Level) *donburi.Entry {
+	entry := world.Entry(world.Create(component.Currents))
+	component.Currents.SetValue(entry, component.CurrentsData{
+		Zones: level.Currents,
+	})
+	for _, zone := range level.Currents {
+		if len(zone.Area.Points) < 3 {
+			continue
+		}
+		minX, minY := zone.Area.Points[0].X, zone.Area.Points[0].Y
+		maxX, maxY := minX, minY
+		for _, point := range zone.Area.Points[1:] {
+			if point.X < minX {
+				minX = point.X
+			}
+			if point.X > maxX {
+				maxX = point.X
+			}
+			if point.Y < minY {
+				minY = point.Y
+			}
+			if point.Y > maxY {
+				maxY = point.Y
+			}
+		}
+		width, height := int(maxX-minX)+1, int(maxY-minY)+1
+
+		dc := gg.NewContext(width, height)
+		dc.MoveTo(zone.Area.Points[0].X-minX, zone.Area.Points[0].Y-minY)
+		for _, point := range zone.Area.Points[1:] {
+			dc.LineTo(point.X-minX, point.Y-minY)
+		}
+		dc.ClosePath()
+		dc.SetColor(color.White)
+		dc.Fill()
+
+		overlay := world.Entry(world.Create(transform.Transform, component.Sprite, component.CurrentOverlay))
+		component.Sprite.SetValue(overlay, component.SpriteData{
+			Image: ebiten.NewImage(width, height),
+			Layer: component.SpriteLayerBackground,
+			Pivot: component.SpritePivotTopLeft,
+		})
+		component.CurrentOverlay.SetValue(overlay, component.CurrentOverlayData{
+			Zone: zone,
+			Mask: ebiten.NewImageFromImage(dc.Image()),
+		})
+		transform.Transform.Get(overlay).LocalPosition = math.Vec2{X: minX, Y: minY}
+	}
+	return entry
+}
@@ -0,0 +1,188 @@
+package archetype
+
+import (
+	"amaru/assets"
+	"amaru/component"
+	"image/color"
+	gomath "math"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/features/transform"
+)
+
+// frames of the whirlpool animation
+const whirlpoolFrames = 8
+
+// NewHazards adds the whirlpools, reefs and storms of the level, each one
+// with a static shape the colliders use to find the boats on it.
+func NewHazards(world donburi.World, space *cp.Space, debug *component.DebugData, level *assets.Level) *donburi.Entry {
+	entry := world.Entry(world.Create(component.Hazards))
+	hazards := &component.HazardsData{
+		Reefs: append([]assets.Reef{}, level.Reefs...),
+	}
+
+	for _, zone := range level.Whirlpools {
+		minX, minY, maxX, maxY := pathBounds(zone.Area)
+		radius := gomath.Min(maxX-minX, maxY-minY) / 2
+		center := cp.Vector{X: (minX + maxX) / 2, Y: (minY + maxY) / 2}
+		whirlpool := &component.WhirlpoolData{
+			Zone:   zone,
+			Frames: whirlpoolImages(radius),
+		}
+
+		body := cp.NewStaticBody()
+		body.SetPosition(center)
+		shape := cp.NewCircle(body, radius, cp.Vector{})
+		shape.SetCollisionType(component.WhirlpoolCollisionType)
+		shape.UserData = &whirlpool.Zone
+		space.AddShape(shape)
+		debug.Shapes = append(debug.Shapes, shape)
+
+		whirlpool.Sprite = world.Entry(world.Create(transform.Transform, component.Sprite))
+		component.Sprite.SetValue(whirlpool.Sprite, component.SpriteData{
+			Image: whirlpool.Frames[0],
+			Layer: component.SpriteLayerBackground,
+			Pivot: component.SpritePivotTopLeft,
+		})
+		transform.Transform.Get(whirlpool.Sprite).LocalPosition = math.Vec2{X: center.X - radius, Y: center.Y - radius + 32}
+		hazards.Whirlpools = append(hazards.Whirlpools, whirlpool)
+	}
+
+	for i := range hazards.Reefs {
+		reef := &hazards.Reefs[i]
+		minX, minY, maxX, maxY := pathBounds(reef.Area)
+		shape := CreateBoxFromPath(space, reef.Area, component.ReefCollisionType)
+		shape.UserData = reef
+		debug.Shapes = append(debug.Shapes, shape)
+
+		sprite := world.Entry(world.Create(transform.Transform, component.Sprite))
+		component.Sprite.SetValue(sprite, component.SpriteData{
+			Image: reefImage(int(maxX-minX), int(maxY-minY)),
+			Layer: component.SpriteLayerBackground,
+			Pivot: component.SpritePivotTopLeft,
+		})
+		transform.Transform.Get(sprite).LocalPosition = math.Vec2{X: minX, Y: minY + 32}
+	}
+
+	for _, zone := range level.Storms {
+		minX, minY, maxX, maxY := pathBounds(zone.Area)
+		storm := &component.StormData{
+			Zone: zone,
+		}
+		shape := CreateBoxFromPath(space, zone.Area, component.StormCollisionType)
+		shape.UserData = storm
+		debug.Shapes = append(debug.Shapes, shape)
+
+		storm.Sprite = world.Entry(world.Create(transform.Transform, component.Sprite))
+		component.Sprite.SetValue(storm.Sprite, component.SpriteData{
+			Image:  stormImage(int(maxX-minX), int(maxY-minY), zone.Direction),
+			Layer:  component.SpriteLayerForeground,
+			Pivot:  component.SpritePivotTopLeft,
+			Hidden: true,
+		})
+		transform.Transform.Get(storm.Sprite).LocalPosition = math.Vec2{X: minX, Y: minY + 32}
+		hazards.Storms = append(hazards.Storms, storm)
+	}
+
+	component.Hazards.Set(entry, hazards)
+	return entry
+}
+
+// SetStorms starts and stops the storms, the host decides and peers follow.
+func SetStorms(hazards *component.HazardsData, active []bool) {
+	for i, storm := range hazards.Storms {
+		if i >= len(active) {
+			return
+		}
+		storm.Active = active[i]
+		component.Sprite.Get(storm.Sprite).Hidden = !storm.Active
+	}
+}
+
+func pathBounds(path assets.Path) (float64, float64, float64, float64) {
+	minX, minY := path.Points[0].X, path.Points[0].Y
+	maxX, maxY := minX, minY
+	for _, point := range path.Points[1:] {
+		minX = gomath.Min(minX, point.X)
+		minY = gomath.Min(minY, point.Y)
+		maxX = gomath.Max(maxX, point.X)
+		maxY = gomath.Max(maxY, point.Y)
+	}
+	return minX, minY, maxX, maxY
+}
+
+// whirlpoolImages draws the spiral arms of a whirlpool turning a little more
+// on every frame.
+func whirlpoolImages(radius float64) []*ebiten.Image {
+	size := int(radius * 2)
+	frames := make([]*ebiten.Image, whirlpoolFrames)
+	for frame := range frames {
+		dc := gg.NewContext(size, size)
+		dc.DrawCircle(radius, radius, radius)
+		dc.SetColor(color.NRGBA{R: 8, G: 40, B: 72, A: 120})
+		dc.Fill()
+		dc.SetColor(color.NRGBA{R: 220, G: 240, B: 255, A: 150})
+		dc.SetLineWidth(3)
+		offset := float64(frame) / whirlpoolFrames * 2 * gomath.Pi / 3
+		for arm := 0; arm < 3; arm++ {
+			start := offset + float64(arm)*2*gomath.Pi/3
+			for step := 0; step <= 24; step++ {
+				t := float64(step) / 24
+				angle := start + t*2*gomath.Pi
+				distance := radius * (1 - t) * 0.95
+				x, y := radius+gomath.Cos(angle)*distance, radius+gomath.Sin(angle)*distance
+				if step == 0 {
+					dc.MoveTo(x, y)
+				} else {
+					dc.LineTo(x, y)
+				}
+			}
+			dc.Stroke()
+		}
+		frames[frame] = ebiten.NewImageFromImage(dc.Image())
+	}
+	return frames
+}
+
+func reefImage(width, height int) *ebiten.Image {
+	dc := gg.NewContext(width, height)
+	dc.DrawRoundedRectangle(2, 2, float64(width-4), float64(height-4), 16)
+	dc.SetColor(color.NRGBA{R: 250, G: 250, B: 240, A: 90})
+	dc.Fill()
+	columns, rows := width/24, height/24
+	for column := 0; column < columns; column++ {
+		for row := 0; row < rows; row++ {
+			x := (float64(column) + 0.5) * float64(width) / float64(columns)
+			y := (float64(row) + 0.5) * float64(height) / float64(rows)
+			dc.DrawEllipse(x, y, 10, 7)
+			dc.SetColor(color.RGBA{R: 120, G: 96, B: 80, A: 255})
+			dc.FillPreserve()
+			dc.SetColor(color.RGBA{R: 70, G: 56, B: 48, A: 255})
+			dc.SetLineWidth(2)
+			dc.Stroke()
+		}
+	}
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// stormImage darkens the storm zone and draws rain falling with the wind.
+func stormImage(width, height int, direction math.Vec2) *ebiten.Image {
+	dc := gg.NewContext(width, height)
+	dc.DrawRectangle(0, 0, float64(width), float64(height))
+	dc.SetColor(color.NRGBA{R: 20, G: 24, B: 40, A: 90})
+	dc.Fill()
+	dc.SetColor(color.NRGBA{R: 200, G: 210, B: 230, A: 140})
+	dc.SetLineWidth(1)
+	dx, dy := direction.X*6, 12+direction.Y*6
+	for x := 0; x < width; x += 16 {
+		for y := (x / 16 % 2) * 12; y < height; y += 24 {
+			dc.DrawLine(float64(x), float64(y), float64(x)+dx, float64(y)+dy)
+			dc.Stroke()
+		}
+	}
+	return ebiten.NewImageFromImage(dc.Image())
+}
@@ -4,7 +4,6 @@ import (
 	"amaru/assets"
 	"amaru/component"
 	"amaru/engine"
-	"time"
 
 	"github.com/jakecoffman/cp"
 	"github.com/yohamta/donburi"
@@ -12,6 +11,9 @@ import (
 	"github.com/yohamta/donburi/query"
 )
 
+// half the thickness of the coastlines read from polylines
+const segmentRadius = 4.0
+
 func MustFindPhysics(w donburi.World) (*component.PhysicsData, *donburi.Entry) {
 	physics, ok := query.NewQuery(filter.Contains(component.Physics)).First(w)
 	if !ok {
@@ -26,17 +28,7 @@ func SetupColliders(world donburi.World) {
 	game := component.MustFindGame(world)
 	boxCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.BoxCollisionType)
 	boxCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
-		playerShape, boxShape := arb.Shapes()
-		playerPoly, ok := playerShape.Class.(*cp.PolyShape)
-		if !ok {
-			// Handle the case where the player shape is not a polygon shape
-			return false
-		}
-		boxPoly, ok := boxShape.Class.(*cp.PolyShape)
-		if !ok {
-			// Handle the case where the box shape is not a polygon shape
-			return false
-		}
+		playerShape, _ := arb.Shapes()
 
 		if playerShape.UserData != nil {
 			playerEntry := playerShape.UserData.(*donburi.Entry)
@@ -54,11 +46,9 @@ func SetupColliders(world donburi.World) {
 				return true
 			}
 
-			overlapDirection := engine.OverlapSide(boxPoly.BB(), playerPoly.BB())
-			if vector.X == overlapDirection.X && overlapDirection.X != 0 {
-				player.Collision = true
-				return player.Collision
-			} else if vector.Y == overlapDirection.Y && overlapDirection.Y != 0 {
+			// the normal points from the boat to the coast, boxes, polygons and
+			// polylines stop the boat the same when it moves against them
+			if vector.Dot(arb.Normal()) > 0 {
 				player.Collision = true
 				return player.Collision
 			}
@@ -91,18 +81,21 @@ func SetupColliders(world donburi.World) {
 			component.Sprite.Get(wasteEntry).Hidden = true
 			return false
 		}
-		waste.Collected = true
-		wLocation.Collected = true
-		component.Sprite.Get(wasteEntry).Hidden = true
-
-		game.Session.RemoteClient.GameData.SessionParticipants[player.ID].Score += component.WastePoints
-		game.CollectedWaste += 1
-
-		if player.Local {
-			if !game.Muted {
-				PlayCollectedAudio()
+		// oil slicks are cleaned by going around them, see system.WasteEffects
+		if waste.Type.Effect == assets.WasteEffectCircle {
+			return false
+		}
+		if !ValidatePickup(world, player, wasteShape.BB()) {
+			return false
+		}
+		helpers := []*component.PlayerData{player}
+		if waste.Type.Effect == assets.WasteEffectHeavy {
+			helpers = wasteHelpers(game, waste, player)
+			if helpers == nil {
+				return false
 			}
 		}
+		CollectWaste(game, waste, helpers)
 
 		return false
 	}
@@ -123,22 +116,101 @@ func SetupColliders(world donburi.World) {
 		player := component.Player.Get(playerEntry)
 		animal := component.Animal.Get(animalEntry)
 
-		if animal.Collected {
+		// the host owns the animals, peers get the rescues with their state
+		if animal.Collected || game.Session.Type != component.SessionTypeHost {
+			return false
+		}
+		// freeing an entangled animal takes a slow approach
+		if animal.Entangled && player.Body.Velocity().Length() > animal.Species.RescueSpeed {
+			return false
+		}
+		if !ValidatePickup(world, player, animalShape.BB()) {
+			return false
+		}
+
+		RescueAnimal(game, animal, player.ID)
+
+		return false
+	}
+	powerUpCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.PowerUpCollisionType)
+	powerUpCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
+		playerShape, powerUpShape := arb.Shapes()
+		if playerShape.UserData == nil || powerUpShape.UserData == nil {
 			return false
 		}
+		playerEntry := playerShape.UserData.(*donburi.Entry)
+		powerUpEntry := powerUpShape.UserData.(*donburi.Entry)
 
-		animal.Collected = true
-		component.Sprite.Get(animalEntry).Hidden = true
-		game.Session.RemoteClient.GameData.SessionParticipants[player.ID].Score += component.AnimalPoints
+		if playerEntry == nil || powerUpEntry == nil || !world.Valid(playerEntry.Entity()) || !world.Valid(powerUpEntry.Entity()) {
+			return false
+		}
 
-		if player.Local {
-			if !game.Muted {
+		player := component.Player.Get(playerEntry)
+		powerUp := component.PowerUp.Get(powerUpEntry)
+
+		// the host hands out the power-ups, peers get them with the claims
+		if powerUp.Collected || game.Session.Type != component.SessionTypeHost {
+			return false
+		}
+		if !ValidatePickup(world, player, powerUpShape.BB()) {
+			return false
+		}
+
+		ClaimPowerUp(game, powerUp, player)
+		game.Session.RemoteClient.SendPowerUpClaimMessage(powerUp.Id, player.ID)
+
+		return false
+	}
+	whirlpoolCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.WhirlpoolCollisionType)
+	whirlpoolCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
+		playerShape, whirlpoolShape := arb.Shapes()
+		player := hazardPlayer(world, playerShape)
+		whirlpool, ok := whirlpoolShape.UserData.(*assets.Whirlpool)
+		if player == nil || !ok {
+			return false
+		}
+		// the player system pulls the boat while it is marked
+		player.Whirlpool = whirlpool
+		player.WhirlpoolFrames = component.HazardContactFrames
+
+		return false
+	}
+	reefCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.ReefCollisionType)
+	reefCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
+		playerShape, reefShape := arb.Shapes()
+		player := hazardPlayer(world, playerShape)
+		reef, ok := reefShape.UserData.(*assets.Reef)
+		if player == nil || !ok {
+			return false
+		}
+		// a longer slow from a net wins over the reef
+		if reef.Slow > 0 && player.SlowFrames <= component.HazardContactFrames {
+			player.SlowFrames = component.HazardContactFrames
+			player.SlowFactor = reef.Slow
+		}
+		if reef.Stun > 0 && player.StunCooldown == 0 {
+			player.StunFrames = int(reef.Stun * 60)
+			player.StunCooldown = player.StunFrames + component.ReefStunCooldown
+			if player.Local && !game.Muted {
 				PlayShipAudio()
 			}
 		}
 
 		return false
 	}
+	stormCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.StormCollisionType)
+	stormCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
+		playerShape, stormShape := arb.Shapes()
+		player := hazardPlayer(world, playerShape)
+		storm, ok := stormShape.UserData.(*component.StormData)
+		if player == nil || !ok {
+			return false
+		}
+		player.Storm = storm
+		player.StormFrames = component.HazardContactFrames
+
+		return false
+	}
 	playersCollisionHandler := physics.Space.NewCollisionHandler(component.PlayerCollisionType, component.PlayerCollisionType)
 	playersCollisionHandler.PreSolveFunc = func(arb *cp.Arbiter, space *cp.Space, userData interface{}) bool {
 		onePlayerShape, otherPlayerShape := arb.Shapes()
@@ -155,39 +227,40 @@ func SetupColliders(world donburi.World) {
 		onePlayer := component.Player.Get(onePlayerEntry)
 		otherPlayer := component.Player.Get(otherPlayerEntry)
 
-		if onePlayer.LastPlayerCollision == nil {
-			game.Session.RemoteClient.GameData.SessionParticipants[onePlayer.ID].Score -= component.PlayerCollisionPoints
-			onePlayer.LastPlayerCollision = engine.Ptr(time.Now())
-			onePlayer.PlayerCollision = true
-			otherPlayer.PlayerCollision = true
-		} else if onePlayer.LastPlayerCollision != nil && time.Since(*onePlayer.LastPlayerCollision).Seconds() > 2 {
-			game.Session.RemoteClient.GameData.SessionParticipants[onePlayer.ID].Score -= component.PlayerCollisionPoints
-			onePlayer.LastPlayerCollision = engine.Ptr(time.Now())
-			onePlayer.PlayerCollision = true
-			otherPlayer.PlayerCollision = true
-		}
-		if otherPlayer.LastPlayerCollision == nil {
-			game.Session.RemoteClient.GameData.SessionParticipants[otherPlayer.ID].Score -= component.PlayerCollisionPoints
-			otherPlayer.LastPlayerCollision = engine.Ptr(time.Now())
-			onePlayer.PlayerCollision = true
-			otherPlayer.PlayerCollision = true
-		} else if otherPlayer.LastPlayerCollision != nil && time.Since(*otherPlayer.LastPlayerCollision).Seconds() > 2 {
-			game.Session.RemoteClient.GameData.SessionParticipants[otherPlayer.ID].Score -= component.PlayerCollisionPoints
-			otherPlayer.LastPlayerCollision = engine.Ptr(time.Now())
-			onePlayer.PlayerCollision = true
-			otherPlayer.PlayerCollision = true
-		}
-		if onePlayer.Local {
-			if !game.Muted {
-				PlayShipAudio()
-			}
+		// the host works out the bumps, peers get them with a message
+		if game.Session.Type == component.SessionTypeHost && onePlayer.BumpCooldown == 0 && otherPlayer.BumpCooldown == 0 {
+			bump := NewBump(game, onePlayer, otherPlayer)
+			ApplyBump(game, onePlayer, otherPlayer, bump)
+			game.Session.RemoteClient.SendBumpMessage(bump)
 		}
 
 		return true
 	}
 }
 
+func hazardPlayer(world donburi.World, playerShape *cp.Shape) *component.PlayerData {
+	if playerShape.UserData == nil {
+		return nil
+	}
+	playerEntry := playerShape.UserData.(*donburi.Entry)
+	if playerEntry == nil || !world.Valid(playerEntry.Entity()) {
+		return nil
+	}
+	return component.Player.Get(playerEntry)
+}
+
 func CreateBoxFromPath(space *cp.Space, path assets.Path, collisionType cp.CollisionType) *cp.Shape {
+	// Create a static body (non-moving)
+	return createBox(space, cp.NewStaticBody(), path, collisionType)
+}
+
+// CreateKinematicBoxFromPath creates a box for things the game moves by hand,
+// like drifting waste, the space keeps their index up to date every step.
+func CreateKinematicBoxFromPath(space *cp.Space, path assets.Path, collisionType cp.CollisionType) *cp.Shape {
+	return createBox(space, space.AddBody(cp.NewKinematicBody()), path, collisionType)
+}
+
+func createBox(space *cp.Space, body *cp.Body, path assets.Path, collisionType cp.CollisionType) *cp.Shape {
 	// Find the minimum and maximum X and Y coordinates in the path
 	minX, minY := path.Points[0].X, path.Points[0].Y
 	maxX, maxY := path.Points[0].X, path.Points[0].Y
@@ -212,11 +285,9 @@ func CreateBoxFromPath(space *cp.Space, path assets.Path, collisionType cp.Colli
 	width := maxX - minX
 	height := maxY - minY
 
-	// Create a static body (non-moving)
-	body := cp.NewStaticBody()
 	body.SetPosition(center)
 
-	// Create a box shape and add it to the static body
+	// Create a box shape and add it to the body
 	shape := cp.NewBox(body, width, height, 0)
 	shape.SetElasticity(1)
 	shape.SetFriction(1)
@@ -228,15 +299,79 @@ func CreateBoxFromPath(space *cp.Space, path assets.Path, collisionType cp.Colli
 	return shape
 }
 
+// MoveKinematicShape centers a shape of a kinematic body at the position, the
+// next step of the space reindexes it.
+func MoveKinematicShape(shape *cp.Shape, center cp.Vector) {
+	shape.Body().SetPosition(center)
+}
+
 func SetupSpaceForLevel(level *assets.Level) (*cp.Space, []*cp.Shape) {
 	space := cp.NewSpace()
 	space.SetGravity(cp.Vector{X: 0, Y: 0})
 	shapes := []*cp.Shape{}
 	// Add paths to the space as static shapes
 	for _, path := range level.Paths {
-		if path.Loops && len(path.Points) == 4 {
+		if len(path.Points) < 2 {
+			continue
+		}
+		if !path.Loops {
+			shapes = append(shapes, CreateSegmentsFromPath(space, path, component.BoxCollisionType)...)
+		} else if isAxisAlignedBox(path) {
 			shapes = append(shapes, CreateBoxFromPath(space, path, component.BoxCollisionType))
+		} else {
+			shapes = append(shapes, CreatePolygonsFromPath(space, path, component.BoxCollisionType)...)
 		}
 	}
 	return space, shapes
 }
+
+// CreatePolygonsFromPath splits a closed path in convex polygons, chipmunk
+// only collides convex shapes.
+func CreatePolygonsFromPath(space *cp.Space, path assets.Path, collisionType cp.CollisionType) []*cp.Shape {
+	points := make([]cp.Vector, len(path.Points))
+	for i, point := range path.Points {
+		points[i] = cp.Vector{X: point.X, Y: point.Y}
+	}
+	body := cp.NewStaticBody()
+	shapes := []*cp.Shape{}
+	for _, polygon := range engine.ConvexDecompose(points) {
+		shape := cp.NewPolyShape(body, len(polygon), polygon, cp.NewTransformIdentity(), 0)
+		shape.SetElasticity(1)
+		shape.SetFriction(1)
+		shape.SetCollisionType(collisionType)
+		space.AddShape(shape)
+		shapes = append(shapes, shape)
+	}
+	return shapes
+}
+
+// CreateSegmentsFromPath adds a segment for each line of an open path.
+func CreateSegmentsFromPath(space *cp.Space, path assets.Path, collisionType cp.CollisionType) []*cp.Shape {
+	body := cp.NewStaticBody()
+	shapes := []*cp.Shape{}
+	for i := 1; i < len(path.Points); i++ {
+		from, to := path.Points[i-1], path.Points[i]
+		shape := cp.NewSegment(body, cp.Vector{X: from.X, Y: from.Y}, cp.Vector{X: to.X, Y: to.Y}, segmentRadius)
+		shape.SetElasticity(1)
+		shape.SetFriction(1)
+		shape.SetCollisionType(collisionType)
+		space.AddShape(shape)
+		shapes = append(shapes, shape)
+	}
+	return shapes
+}
+
+// isAxisAlignedBox tells if the path is a box with straight sides, like the
+// ones MustLoadBox reads.
+func isAxisAlignedBox(path assets.Path) bool {
+	if len(path.Points) != 4 {
+		return false
+	}
+	for i, point := range path.Points {
+		next := path.Points[(i+1)%4]
+		if point.X != next.X && point.Y != next.Y {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package archetype
 
 import (
+	"image/color"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	vpad "github.com/kemokemo/ebiten-virtualpad"
 	"github.com/yohamta/donburi"
@@ -15,6 +17,7 @@ import (
 	"amaru/assets"
 	"amaru/component"
 	"amaru/engine"
+	"amaru/net"
 )
 
 var (
@@ -82,6 +85,7 @@ func MustLoadPlayerActions() {
 			continue
 		}
 		ActionsByKey[next.Name] = next
+		net.RegisterWireAnimation(next.Name)
 	}
 }
 
@@ -97,13 +101,7 @@ func NewPlayer(w donburi.World, space *cp.Space, startPosition math.Vec2, intAni
 	shape.SetCollisionType(component.PlayerCollisionType)
 
 	inputs := &component.PlayerSettings{
-		Inputs: component.PlayerInputs{
-			Up:    ebiten.KeyUp,
-			Right: ebiten.KeyRight,
-			Down:  ebiten.KeyDown,
-			Left:  ebiten.KeyLeft,
-			Shoot: ebiten.KeyEnter,
-		},
+		Inputs: component.CurrentProfile.Inputs,
 	}
 	if !localPlayer {
 		inputs = nil
@@ -117,6 +115,8 @@ func NewPlayer(w donburi.World, space *cp.Space, startPosition math.Vec2, intAni
 		Shape:          shape,
 		Space:          space,
 		PlayerSettings: inputs,
+		Hull:           component.FindHull(component.DefaultHull),
+		Tint:           component.FindBoatTint(component.DefaultBoatTint),
 	}
 	pEntity := newPlayerFromPlayerData(w, math.Vec2{X: startPosition.X, Y: startPosition.Y}, intAnim, &player)
 	shape.UserData = pEntity
@@ -160,10 +160,7 @@ func newPlayerFromPlayerData(w donburi.World, startPosition math.Vec2, animation
 	)
 	labelData := component.PlayerLabel.Get(playerLabel)
 	labelData.Name = playerData.Name
-	labelData.Color = colornames.Orange
-	if !playerData.Local {
-		labelData.Color = colornames.Fuchsia
-	}
+	labelData.Color = defaultLabelColor(playerData)
 
 	playerData.Label = playerLabel
 	component.Player.SetValue(player, *playerData)
@@ -172,6 +169,61 @@ func newPlayerFromPlayerData(w donburi.World, startPosition math.Vec2, animation
 	return player
 }
 
+func defaultLabelColor(player *component.PlayerData) color.Color {
+	if !player.Local {
+		return colornames.Fuchsia
+	}
+	return component.CurrentProfile.Color()
+}
+
+// UpdateBoat applies the hull and the color the player picked, remote boats
+// keep the default one until their choice arrives.
+func UpdateBoat(game *component.GameData, player *component.PlayerData) {
+	hull, tint := component.CurrentProfile.Hull, component.CurrentProfile.Tint
+	if !player.Local {
+		boat, ok := game.Session.RemoteClient.Boat(player.ID)
+		if !ok {
+			return
+		}
+		hull, tint = boat.Hull, boat.Tint
+	}
+	player.Hull = component.FindHull(hull)
+	player.Tint = component.FindBoatTint(tint)
+}
+
+// UpdateTeamColors tints the boat and the name label of a player with the
+// colors of its team, players without a team keep their own colors.
+func UpdateTeamColors(game *component.GameData, entry *donburi.Entry, player *component.PlayerData) {
+	sprite := component.Sprite.Get(entry)
+	label := component.PlayerLabel.Get(player.Label)
+	participant := game.Session.RemoteClient.GameData.SessionParticipants[player.ID]
+	if participant == nil || participant.Team == net.TeamNone {
+		sprite.ColorOverride = player.Tint.Tint
+		label.Color = defaultLabelColor(player)
+	} else {
+		sprite.ColorOverride = component.TeamTints[participant.Team]
+		label.Color = component.TeamColors[participant.Team]
+	}
+	// bumped boats flash for a moment
+	if player.BumpFrames > 0 && player.BumpFrames/4%2 == 0 {
+		sprite.ColorOverride = component.BumpFlash
+	}
+}
+
+func FindPlayerByID(w donburi.World, id string) (*component.PlayerData, *donburi.Entry) {
+	var foundPlayer *component.PlayerData
+	var foundPlayerEntry *donburi.Entry
+	query.NewQuery(filter.Contains(component.Player)).Each(w, func(e *donburi.Entry) {
+		player := component.Player.Get(e)
+		if player.ID == id {
+			foundPlayer = player
+			foundPlayerEntry = e
+		}
+	})
+
+	return foundPlayer, foundPlayerEntry
+}
+
 func FindPlayerByName(w donburi.World, name string) (*component.PlayerData, *donburi.Entry) {
 	var foundPlayer *component.PlayerData
 	var foundPlayerEntry *donburi.Entry
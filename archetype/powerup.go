@@ -0,0 +1,96 @@
+package archetype
+
+import (
+	"amaru/assets"
+	"amaru/component"
+	"amaru/net"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/features/transform"
+	"golang.org/x/image/colornames"
+)
+
+// the host gives remote boats a little longer so their power-ups do not end
+// before the claim reaches them
+const powerUpLatencyFrames = 30
+
+// powerUpImages caches the icon of each power-up kind
+var powerUpImages = map[string]*ebiten.Image{}
+
+func powerUpImage(kind string) *ebiten.Image {
+	if img, ok := powerUpImages[kind]; ok {
+		return img
+	}
+	dc := gg.NewContext(32, 32)
+	dc.DrawCircle(16, 16, 14)
+	dc.SetColor(component.PowerUpColors[kind])
+	dc.FillPreserve()
+	dc.SetColor(colornames.White)
+	dc.SetLineWidth(2)
+	dc.Stroke()
+	dc.SetFontFace(assets.MainFont)
+	dc.DrawStringAnchored(component.PowerUpLabels[kind][:1], 16, 15, 0.5, 0.5)
+	img := ebiten.NewImageFromImage(dc.Image())
+	powerUpImages[kind] = img
+	return img
+}
+
+// PlacePowerUp adds a power-up the host placed to the world.
+func PlacePowerUp(world donburi.World, space *cp.Space, debug *component.DebugData, location *net.PowerUpLocation) *component.PowerUpData {
+	center := cp.Vector{X: location.Position.X, Y: location.Position.Y}
+	shape := CreateBoxFromPath(space, assets.Path{
+		Points: []math.Vec2{
+			{X: center.X - 16, Y: center.Y - 16},
+			{X: center.X + 16, Y: center.Y - 16},
+			{X: center.X + 16, Y: center.Y + 16},
+			{X: center.X - 16, Y: center.Y + 16},
+		},
+		Loops: true,
+	}, component.PowerUpCollisionType)
+	debug.Shapes = append(debug.Shapes, shape)
+
+	entry := world.Entry(world.Create(component.Sprite, transform.Transform, component.PowerUp))
+	shape.UserData = entry
+	powerUp := &component.PowerUpData{
+		Id:        location.Id,
+		Kind:      location.Kind,
+		Collected: location.Collected,
+		Shape:     shape,
+		Entry:     entry,
+	}
+	component.PowerUp.SetValue(entry, *powerUp)
+	component.Sprite.SetValue(entry, component.SpriteData{
+		Image:  powerUpImage(location.Kind),
+		Layer:  component.SpriteLayerDefault,
+		Pivot:  component.SpritePivotTopLeft,
+		Hidden: location.Collected,
+	})
+	transform.Transform.Get(entry).LocalPosition = math.Vec2{X: center.X - 16, Y: center.Y + 16}
+	return component.PowerUp.Get(entry)
+}
+
+// ClaimPowerUp gives the power-up to the boat, the host calls it on pickups
+// and peers when the host reports them.
+func ClaimPowerUp(game *component.GameData, powerUp *component.PowerUpData, player *component.PlayerData) {
+	powerUp.Collected = true
+	component.Sprite.Get(powerUp.Entry).Hidden = true
+	if location := game.Session.RemoteClient.GameData.PowerUps[powerUp.Id]; location != nil {
+		location.Collected = true
+		location.CollectedBy = player.ID
+	}
+	if player.PowerUps == nil {
+		player.PowerUps = map[string]int{}
+	}
+	frames := component.PowerUpFrames[powerUp.Kind]
+	if !player.Local && game.Session.Type == component.SessionTypeHost {
+		frames += powerUpLatencyFrames
+	}
+	player.PowerUps[powerUp.Kind] = frames
+	if player.Local && !game.Muted {
+		PlayCollectedAudio()
+	}
+}
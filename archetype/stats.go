@@ -0,0 +1,46 @@
+package archetype
+
+import (
+	"amaru/component"
+	"amaru/net"
+	"time"
+)
+
+// RecordMatch stores the result of the match that just ended for the local
+// player. It must run before the next level is picked.
+func RecordMatch(game *component.GameData) {
+	remoteClient := game.Session.RemoteClient
+	// players that join on the podium did not play the match
+	if remoteClient == nil || remoteClient.GameData == nil || remoteClient.Client.Id == nil || game.Match.Started.IsZero() {
+		return
+	}
+	local := remoteClient.GameData.SessionParticipants[*remoteClient.Client.Id]
+	if local == nil {
+		return
+	}
+	record := component.MatchRecord{
+		Date:       time.Now(),
+		Level:      remoteClient.GameData.LevelIndex,
+		Duration:   time.Since(game.Match.Started).Round(time.Second),
+		Score:      local.Totals.Score,
+		Waste:      game.Match.Waste,
+		Animals:    game.Match.Animals,
+		Collisions: game.Match.Collisions,
+		Players:    len(remoteClient.GameData.SessionParticipants),
+	}
+	for _, result := range net.RankStandings(remoteClient.GameData.SessionParticipants) {
+		if result.Participant.Id == local.Id {
+			record.Rank = result.Rank
+			continue
+		}
+		record.Opponents = append(record.Opponents, net.ParticipantName(result.Participant))
+	}
+	component.CurrentStats.Record(record)
+	component.CurrentStats.Save()
+}
+
+func recordCollision(game *component.GameData, player *component.PlayerData) {
+	if player.Local {
+		game.Match.Collisions++
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"amaru/engine"
 	"fmt"
 	"math/rand"
+	"time"
 
 	"github.com/jakecoffman/cp"
 	"github.com/yohamta/donburi"
@@ -13,33 +14,36 @@ import (
 	"github.com/yohamta/donburi/features/transform"
 )
 
-var (
-	WasteAnimationAction = &component.Animation{
-		Name:   "default",
-		Frames: []int{0, 1, 2, 3, 4, 5},
+// wasteActions keeps one animation per waste type, waste of a type shares it
+var wasteActions = map[string][]*component.Animation{}
+
+func wasteTypeActions(wasteType *assets.WasteType) []*component.Animation {
+	actions, ok := wasteActions[wasteType.Name]
+	if !ok {
+		actions = []*component.Animation{{
+			Name:   "default",
+			Frames: wasteType.Frames,
+		}}
+		wasteActions[wasteType.Name] = actions
 	}
+	return actions
+}
 
-	WasteActions = []*component.Animation{WasteAnimationAction}
+const (
+	// free spots tried before a pollution source skips a spawn
+	spawnAttempts = 10
 )
 
 func PlaceWasteComponents(world donburi.World, space *cp.Space, numWaste int, debug *component.DebugData, shapeList []cp.BB, mapWidth float64, mapHeight float64) []*component.WasteData {
 	wasteList := []*component.WasteData{}
 	for i := 0; i < numWaste; i++ {
+		wasteType := assets.RandomWasteType()
 		for {
 			x := rand.Float64() * mapWidth
 			y := rand.Float64() * mapHeight
 
-			points := []math.Vec2{}
-			points = append(points, math.Vec2{X: x, Y: y - 32})
-			points = append(points, math.Vec2{X: x + 32, Y: y - 32})
-			points = append(points, math.Vec2{X: x + 32, Y: y})
-			points = append(points, math.Vec2{X: x, Y: y})
-
-			wastePath := assets.Path{
-				Points: points,
-				Loops:  true,
-			}
-			wasteShape := CreateBoxFromPath(space, wastePath, component.WasteCollisionType)
+			wastePath := wastePathAt(x, y, wasteType)
+			wasteShape := CreateKinematicBoxFromPath(space, wastePath, component.WasteCollisionType)
 
 			overlapping := false
 			for _, waste := range wasteList {
@@ -57,7 +61,7 @@ func PlaceWasteComponents(world donburi.World, space *cp.Space, numWaste int, de
 			}
 
 			if !overlapping {
-				newWaste := placeWasteFromPath(world, space, debug, fmt.Sprint(i), wastePath, false, wasteShape)
+				newWaste := placeWasteFromPath(world, space, debug, fmt.Sprint(i), wasteType, wastePath, false, wasteShape)
 				wasteList = append(wasteList, newWaste)
 				break
 			}
@@ -66,22 +70,98 @@ func PlaceWasteComponents(world donburi.World, space *cp.Space, numWaste int, de
 	return wasteList
 }
 
-func PlaceRemoteWasteFromPath(world donburi.World, space *cp.Space, debug *component.DebugData, id string, wastePath assets.Path, collected bool) *component.WasteData {
-	return placeWasteFromPath(world, space, debug, id, wastePath, collected, nil)
+func wastePathAt(x float64, y float64, wasteType *assets.WasteType) assets.Path {
+	width, height := float64(wasteType.Width), float64(wasteType.Height)
+	points := []math.Vec2{}
+	points = append(points, math.Vec2{X: x, Y: y - height})
+	points = append(points, math.Vec2{X: x + width, Y: y - height})
+	points = append(points, math.Vec2{X: x + width, Y: y})
+	points = append(points, math.Vec2{X: x, Y: y})
+
+	return assets.Path{
+		Points: points,
+		Loops:  true,
+	}
+}
+
+// wasteSpritePosition is the top left of the sprite for waste centered at the
+// position, sprites are drawn one tile below their shapes.
+func wasteSpritePosition(center cp.Vector, wasteType *assets.WasteType) math.Vec2 {
+	return math.Vec2{X: center.X - float64(wasteType.Width)/2, Y: center.Y - float64(wasteType.Height)/2 + 32}
+}
+
+// SpawnWasteInArea places waste of a random type on a spot of the area that
+// no other shape covers, it returns nil when no free spot was found.
+func SpawnWasteInArea(world donburi.World, space *cp.Space, debug *component.DebugData, id string, area assets.Path) *component.WasteData {
+	if len(area.Points) == 0 {
+		return nil
+	}
+	minX, minY := area.Points[0].X, area.Points[0].Y
+	maxX, maxY := minX, minY
+	for _, point := range area.Points[1:] {
+		if point.X < minX {
+			minX = point.X
+		}
+		if point.X > maxX {
+			maxX = point.X
+		}
+		if point.Y < minY {
+			minY = point.Y
+		}
+		if point.Y > maxY {
+			maxY = point.Y
+		}
+	}
+	// keep the whole waste inside the area
+	wasteType := assets.RandomWasteType()
+	wasteWidth, wasteHeight := float64(wasteType.Width), float64(wasteType.Height)
+	width, height := maxX-minX-wasteWidth, maxY-minY-wasteHeight
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	for attempt := 0; attempt < spawnAttempts; attempt++ {
+		x := minX + rand.Float64()*width
+		y := minY + wasteHeight + rand.Float64()*height
+		free := true
+		space.BBQuery(cp.BB{L: x, B: y - wasteHeight, R: x + wasteWidth, T: y}, cp.SHAPE_FILTER_ALL, func(shape *cp.Shape, data interface{}) {
+			// storms blow over everything else
+			if _, ok := shape.UserData.(*component.StormData); ok {
+				return
+			}
+			free = false
+		}, nil)
+		if free {
+			return placeWasteFromPath(world, space, debug, id, wasteType, wastePathAt(x, y, wasteType), false, nil)
+		}
+	}
+	return nil
+}
+
+// MoveWaste centers the waste shape at the position and moves its sprite.
+func MoveWaste(waste *component.WasteData, center cp.Vector) {
+	MoveKinematicShape(waste.Shape, center)
+	waste.Path = wastePathAt(center.X-float64(waste.Type.Width)/2, center.Y+float64(waste.Type.Height)/2, waste.Type)
+	if waste.Entry != nil {
+		transform.Transform.Get(waste.Entry).LocalPosition = wasteSpritePosition(center, waste.Type)
+	}
+}
+
+func PlaceRemoteWasteFromPath(world donburi.World, space *cp.Space, debug *component.DebugData, id string, wasteType string, wastePath assets.Path, collected bool) *component.WasteData {
+	return placeWasteFromPath(world, space, debug, id, assets.FindWasteType(wasteType), wastePath, collected, nil)
 }
 
-func placeWasteFromPath(world donburi.World, space *cp.Space, debug *component.DebugData, id string, wastePath assets.Path, collected bool, wasteShape *cp.Shape) *component.WasteData {
+func placeWasteFromPath(world donburi.World, space *cp.Space, debug *component.DebugData, id string, wasteType *assets.WasteType, wastePath assets.Path, collected bool, wasteShape *cp.Shape) *component.WasteData {
 	if wasteShape == nil {
-		wasteShape = CreateBoxFromPath(space, wastePath, component.WasteCollisionType)
+		wasteShape = CreateKinematicBoxFromPath(space, wastePath, component.WasteCollisionType)
 	}
 	cameraEntry := MustFindCamera(world)
 	camera := component.Camera.Get(cameraEntry)
-	position := wasteShape.Body().Position()
-	// position is center of shape, shape is 32x32
-	x := position.X - 16
-	y := position.Y + 16
 	newWaste := &component.WasteData{
 		Id:        id,
+		Type:      wasteType,
 		Path:      wastePath,
 		Shape:     wasteShape,
 		Collected: false,
@@ -104,15 +184,73 @@ func placeWasteFromPath(world donburi.World, space *cp.Space, debug *component.D
 		spriteData.Layer = component.SpriteLayerDefault
 		spriteData.Pivot = component.SpritePivotTopLeft
 
-		animationEntry := NewAnimationComponent(world, waste, waste, assets.WasteSpriteSheet.Drawables(), 0.4)
+		animationEntry := NewAnimationComponent(world, waste, waste, wasteType.SpriteSheet.Drawables(), wasteType.Rate)
 		animationData := component.AnimationComponent.Get(animationEntry)
-		animationData.AddAnimations(WasteActions)
+		animationData.AddAnimations(wasteTypeActions(wasteType))
 		animationData.SelectAnimationByName("default")
 		animationData.CurrentAnimation.Loop = true
 
 		spriteData.Image = animationData.Cell()
 		component.Sprite.SetValue(waste, spriteData)
-		transform.Transform.Get(waste).LocalPosition = math.Vec2{X: x, Y: y}
+		transform.Transform.Get(waste).LocalPosition = wasteSpritePosition(wasteShape.Body().Position(), wasteType)
 	}
 	return newWaste
 }
+
+// CollectWaste marks the waste as collected and gives its points to every
+// boat that cleaned it.
+func CollectWaste(game *component.GameData, waste *component.WasteData, players []*component.PlayerData) {
+	waste.Collected = true
+	if location := game.Session.RemoteClient.GameData.WasteLocations[waste.Id]; location != nil {
+		location.Collected = true
+	}
+	if waste.Entry != nil {
+		component.Sprite.Get(waste.Entry).Hidden = true
+	}
+	points := game.Session.RemoteClient.GameData.Rules.WastePoints + waste.Type.Points
+	for _, player := range players {
+		participant := game.Session.RemoteClient.GameData.SessionParticipants[player.ID]
+		if participant != nil {
+			participant.Score += points
+			participant.Round.Waste++
+		}
+		if waste.Type.Effect == assets.WasteEffectSlow {
+			player.SlowFrames = int(waste.Type.SlowSeconds * 60)
+			player.SlowFactor = waste.Type.SlowFactor
+		}
+		if player.Local {
+			game.Match.Waste++
+			if !game.Muted {
+				PlayCollectedAudio()
+			}
+		}
+	}
+	game.CollectedWaste += 1
+}
+
+// wasteHelpers records the boat touching heavy waste and returns the boats
+// that touched it recently, once there are enough of them to lift it.
+func wasteHelpers(game *component.GameData, waste *component.WasteData, player *component.PlayerData) []*component.PlayerData {
+	if waste.Helpers == nil {
+		waste.Helpers = map[string]*component.WasteHelper{}
+	}
+	now := time.Now()
+	waste.Helpers[player.ID] = &component.WasteHelper{Player: player, Last: now}
+	helpers := []*component.PlayerData{}
+	for id, helper := range waste.Helpers {
+		if now.Sub(helper.Last) > component.WasteHelperWindow {
+			delete(waste.Helpers, id)
+			continue
+		}
+		helpers = append(helpers, helper.Player)
+	}
+	// smaller sessions lift it with every boat they have
+	needed := waste.Type.Helpers
+	if participants := len(game.Session.RemoteClient.GameData.SessionParticipants); participants < needed {
+		needed = participants
+	}
+	if len(helpers) < needed {
+		return nil
+	}
+	return helpers
+}
@@ -0,0 +1,14 @@
+package archetype
+
+import (
+	"amaru/component"
+
+	"github.com/yohamta/donburi"
+)
+
+// NewWeather adds the weather of the round rolled from the host seed.
+func NewWeather(world donburi.World, seed int64) *donburi.Entry {
+	entry := world.Entry(world.Create(component.Weather))
+	component.Weather.SetValue(entry, component.NewWeather(seed))
+	return entry
+}
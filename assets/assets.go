@@ -9,6 +9,7 @@ import (
 	_ "image/png"
 	"io"
 	"io/fs"
+	gomath "math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/audio"
@@ -61,12 +62,9 @@ var (
 	MainSmallFont font.Face
 	MainBigFont   font.Face
 
-	BoatSpriteSheet   *engine.Spritesheet
-	WasteSpriteSheet  *engine.Spritesheet
-	TurtleSpriteSheet *engine.Spritesheet
-	SealSpriteSheet   *engine.Spritesheet
-	DirectionalPad    *ebiten.Image
-	DirectionalBtn    *ebiten.Image
+	BoatSpriteSheet *engine.Spritesheet
+	DirectionalPad  *ebiten.Image
+	DirectionalBtn  *ebiten.Image
 
 	BlueColor  color.Color
 	GreenColor color.Color
@@ -97,8 +95,68 @@ type Level struct {
 	OverPlayer   *ebiten.Image
 	Background   *ebiten.Image
 	Paths        map[uint32]Path
-	Animals      []Path
+	Animals      []AnimalSpawn
 	PlayersStart []Path
+	// areas where waste keeps appearing during a round
+	PollutionSources []PollutionSource
+	Currents         []Current
+	Whirlpools       []Whirlpool
+	Reefs            []Reef
+	Storms           []Storm
+}
+
+// Current is read from a "current" polygon of the level map, its "angle"
+// property is the direction in degrees (0 points right, 90 down) and
+// "strength" the drift in pixels per second.
+type Current struct {
+	Area      Path
+	Direction math.Vec2
+	Strength  float64
+}
+
+// Whirlpool is read from a "whirlpool" box of the level map, boats inside
+// its circle are pulled to the center at "pull" pixels per second and spun
+// around it at "spin".
+type Whirlpool struct {
+	Area Path
+	Pull float64
+	Spin float64
+}
+
+// Reef is read from a "reef" box of the level map, "slow" multiplies the
+// speed of the boats on it and "stun" stops them for that many seconds when
+// they run into it.
+type Reef struct {
+	Area Path
+	Slow float64
+	Stun float64
+}
+
+// Storm is read from a "storm" box of the level map, it stays calm for
+// "interval" seconds and then blows for "duration" seconds, pushing the boats
+// inside towards "angle" at "strength" pixels per second.
+type Storm struct {
+	Area      Path
+	Interval  float64
+	Duration  float64
+	Direction math.Vec2
+	Strength  float64
+}
+
+// AnimalSpawn is read from an "animal" object of the level map, its
+// "species" property picks an entry of meta/species.json.
+type AnimalSpawn struct {
+	Area    Path
+	Species string
+}
+
+// PollutionSource is read from a "pollutionSource" object of the level map,
+// its "interval" property is the seconds between spawns and "max" the most
+// waste it adds in a round.
+type PollutionSource struct {
+	Area     Path
+	Interval float64
+	Max      int
 }
 
 type Path struct {
@@ -124,6 +182,18 @@ func (p *Path) TetraCenter() math.Vec2 {
 	return math.Vec2{X: centerX, Y: centerY}
 }
 
+// Contains tells if a point is inside a closed path.
+func (p *Path) Contains(point math.Vec2) bool {
+	inside := false
+	for i, j := 0, len(p.Points)-1; i < len(p.Points); j, i = i, i+1 {
+		a, b := p.Points[i], p.Points[j]
+		if (a.Y > point.Y) != (b.Y > point.Y) && point.X < (b.X-a.X)*(point.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
 type LevelLoader struct {
 	LevelsSize        int
 	CurrentLevel      *Level
@@ -141,9 +211,8 @@ func MustLoadAssets() {
 	MainBigFont = mustLoadFont(mainFontData, 48)
 
 	BoatSpriteSheet = mustLoadNorthSpriteSheet("images/boat.png", 32, 32)
-	WasteSpriteSheet = mustLoadNorthSpriteSheet("images/waste-sheet.png", 32, 32)
-	TurtleSpriteSheet = mustLoadNorthSpriteSheet("images/turtle-sheet.png", 32, 32)
-	SealSpriteSheet = mustLoadNorthSpriteSheet("images/foca-sheet.png", 32, 32)
+	mustLoadWasteTypes()
+	mustLoadSpecies()
 	DirectionalPad = MustLoadImageFromFS("images/directional_pad.png")
 	DirectionalBtn = MustLoadImageFromFS("images/directional_button.png")
 
@@ -225,8 +294,13 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 	nextLevel := Level{}
 
 	paths := map[uint32]Path{}
-	animals := []Path{}
+	animals := []AnimalSpawn{}
 	playerStarts := []Path{}
+	sources := []PollutionSource{}
+	currents := []Current{}
+	whirlpools := []Whirlpool{}
+	reefs := []Reef{}
+	storms := []Storm{}
 	for _, og := range levelMap.ObjectGroups {
 		for _, o := range og.Objects {
 			if o.Width != 0 && o.Height != 0 && len(o.PolyLines) == 0 && len(o.Polygons) == 0 {
@@ -234,7 +308,37 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 				if o.Class == "playerStart" {
 					playerStarts = append(playerStarts, box)
 				} else if o.Class == "animal" {
-					animals = append(animals, box)
+					animals = append(animals, AnimalSpawn{
+						Area:    box,
+						Species: o.Properties.GetString("species"),
+					})
+				} else if o.Class == "pollutionSource" {
+					sources = append(sources, PollutionSource{
+						Area:     box,
+						Interval: o.Properties.GetFloat("interval"),
+						Max:      o.Properties.GetInt("max"),
+					})
+				} else if o.Class == "whirlpool" {
+					whirlpools = append(whirlpools, Whirlpool{
+						Area: box,
+						Pull: o.Properties.GetFloat("pull"),
+						Spin: o.Properties.GetFloat("spin"),
+					})
+				} else if o.Class == "reef" {
+					reefs = append(reefs, Reef{
+						Area: box,
+						Slow: o.Properties.GetFloat("slow"),
+						Stun: o.Properties.GetFloat("stun"),
+					})
+				} else if o.Class == "storm" {
+					angle := o.Properties.GetFloat("angle") * gomath.Pi / 180
+					storms = append(storms, Storm{
+						Area:      box,
+						Interval:  o.Properties.GetFloat("interval"),
+						Duration:  o.Properties.GetFloat("duration"),
+						Direction: math.Vec2{X: gomath.Cos(angle), Y: gomath.Sin(angle)},
+						Strength:  o.Properties.GetFloat("strength"),
+					})
 				} else {
 					paths[o.ID] = box
 				}
@@ -249,10 +353,10 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 						})
 					}
 				}
-				paths[o.ID] = Path{
+				paths[o.ID] = l.shiftPath(Path{
 					Loops:  false,
 					Points: points,
-				}
+				})
 			}
 			if len(o.Polygons) > 0 {
 				var points []math.Vec2
@@ -264,10 +368,22 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 						})
 					}
 				}
-				paths[o.ID] = Path{
+				if o.Class == "current" {
+					angle := o.Properties.GetFloat("angle") * gomath.Pi / 180
+					currents = append(currents, Current{
+						Area: l.shiftPath(Path{
+							Loops:  true,
+							Points: points,
+						}),
+						Direction: math.Vec2{X: gomath.Cos(angle), Y: gomath.Sin(angle)},
+						Strength:  o.Properties.GetFloat("strength"),
+					})
+					continue
+				}
+				paths[o.ID] = l.shiftPath(Path{
 					Loops:  true,
 					Points: points,
-				}
+				})
 			}
 		}
 	}
@@ -301,6 +417,11 @@ func (l *LevelLoader) MustLoadLevel(levelPath string) Level {
 	nextLevel.Paths = paths
 	nextLevel.Animals = animals
 	nextLevel.PlayersStart = playerStarts
+	nextLevel.PollutionSources = sources
+	nextLevel.Currents = currents
+	nextLevel.Whirlpools = whirlpools
+	nextLevel.Reefs = reefs
+	nextLevel.Storms = storms
 
 	return nextLevel
 }
@@ -321,6 +442,15 @@ func (l *LevelLoader) MustLoadBox(o *tiled.Object) Path {
 
 }
 
+// shiftPath moves a polygon or polyline the same as MustLoadBox moves the
+// boxes, so every shape read from the map lines up with it.
+func (l *LevelLoader) shiftPath(path Path) Path {
+	for i := range path.Points {
+		path.Points[i].Y -= 32
+	}
+	return path
+}
+
 func MustLoadImageFromFS(filePath string) *ebiten.Image {
 	file, err := assetsFS.ReadFile(filePath)
 	if err != nil {
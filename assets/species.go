@@ -0,0 +1,74 @@
+package assets
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"amaru/engine"
+)
+
+var (
+	//go:embed meta/species.json
+	speciesData []byte
+
+	// Species holds the animal species by name, SpeciesNames keeps the order
+	// of meta/species.json
+	Species      map[string]*AnimalSpecies
+	SpeciesNames []string
+)
+
+// AnimalSpecies describes how an animal looks, moves and is rescued. Speeds
+// are in pixels per second, points are added to the animal points of the
+// rules and a flee radius of 0 makes the animal ignore the boats.
+type AnimalSpecies struct {
+	Name           string  `json:"name"`
+	Sheet          string  `json:"sheet"`
+	FrameWidth     int     `json:"frameWidth"`
+	FrameHeight    int     `json:"frameHeight"`
+	Frames         []int   `json:"frames"`
+	Rate           float32 `json:"rate"`
+	Points         int     `json:"points"`
+	SwimSpeed      float64 `json:"swimSpeed"`
+	FleeSpeed      float64 `json:"fleeSpeed"`
+	FleeRadius     float64 `json:"fleeRadius"`
+	EntangleChance float64 `json:"entangleChance"`
+	RescueSpeed    float64 `json:"rescueSpeed"`
+
+	SpriteSheet *engine.Spritesheet `json:"-"`
+}
+
+type speciesFile struct {
+	Species []*AnimalSpecies `json:"species"`
+}
+
+func mustLoadSpecies() {
+	var file speciesFile
+	if err := json.Unmarshal(speciesData, &file); err != nil {
+		panic(err)
+	}
+	if len(file.Species) == 0 {
+		panic("meta/species.json has no species")
+	}
+	Species = map[string]*AnimalSpecies{}
+	SpeciesNames = []string{}
+	sheets := map[string]*engine.Spritesheet{}
+	for _, species := range file.Species {
+		sheet := sheets[species.Sheet]
+		if sheet == nil {
+			sheet = mustLoadNorthSpriteSheet(species.Sheet, species.FrameWidth, species.FrameHeight)
+			sheets[species.Sheet] = sheet
+		}
+		species.SpriteSheet = sheet
+		Species[species.Name] = species
+		SpeciesNames = append(SpeciesNames, species.Name)
+	}
+}
+
+// FindSpecies returns the species with the name, animals without a known
+// species take turns through the registry by index.
+func FindSpecies(name string, index int) *AnimalSpecies {
+	if species, ok := Species[name]; ok {
+		return species
+	}
+	return Species[SpeciesNames[index%len(SpeciesNames)]]
+}
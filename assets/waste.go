@@ -0,0 +1,99 @@
+package assets
+
+import (
+	_ "embed"
+	"encoding/json"
+	"math/rand"
+
+	"amaru/engine"
+)
+
+const (
+	WasteEffectNone = ""
+	// the boat picking it up is slowed down for a while
+	WasteEffectSlow = "slow"
+	// boats clean it by going around it
+	WasteEffectCircle = "circle"
+	// it takes several boats touching it together
+	WasteEffectHeavy = "heavy"
+)
+
+var (
+	//go:embed meta/waste.json
+	wasteTypesData []byte
+
+	// WasteTypes holds the waste types by name, WasteTypeNames keeps the order
+	// of meta/waste.json
+	WasteTypes     map[string]*WasteType
+	WasteTypeNames []string
+)
+
+// WasteType describes how a kind of waste looks, what it is worth and how it
+// is cleaned. Points are added to the waste points of the rules and weight is
+// how often it shows up compared with the other types.
+type WasteType struct {
+	Name         string  `json:"name"`
+	Sheet        string  `json:"sheet"`
+	Width        int     `json:"width"`
+	Height       int     `json:"height"`
+	Frames       []int   `json:"frames"`
+	Rate         float32 `json:"rate"`
+	Points       int     `json:"points"`
+	Weight       int     `json:"weight"`
+	Effect       string  `json:"effect"`
+	SlowFactor   float64 `json:"slowFactor"`
+	SlowSeconds  float64 `json:"slowSeconds"`
+	CircleRadius float64 `json:"circleRadius"`
+	Helpers      int     `json:"helpers"`
+
+	SpriteSheet *engine.Spritesheet `json:"-"`
+}
+
+type wasteTypesFile struct {
+	Types []*WasteType `json:"types"`
+}
+
+func mustLoadWasteTypes() {
+	var file wasteTypesFile
+	if err := json.Unmarshal(wasteTypesData, &file); err != nil {
+		panic(err)
+	}
+	if len(file.Types) == 0 {
+		panic("meta/waste.json has no waste types")
+	}
+	WasteTypes = map[string]*WasteType{}
+	WasteTypeNames = []string{}
+	for _, wasteType := range file.Types {
+		wasteType.SpriteSheet = mustLoadNorthSpriteSheet(wasteType.Sheet, wasteType.Width, wasteType.Height)
+		WasteTypes[wasteType.Name] = wasteType
+		WasteTypeNames = append(WasteTypeNames, wasteType.Name)
+	}
+}
+
+// FindWasteType returns the waste type with the name, unknown names get the
+// first type of the registry.
+func FindWasteType(name string) *WasteType {
+	if wasteType, ok := WasteTypes[name]; ok {
+		return wasteType
+	}
+	return WasteTypes[WasteTypeNames[0]]
+}
+
+// RandomWasteType picks a waste type by weight.
+func RandomWasteType() *WasteType {
+	total := 0
+	for _, name := range WasteTypeNames {
+		total += WasteTypes[name].Weight
+	}
+	if total <= 0 {
+		return FindWasteType("")
+	}
+	pick := rand.Intn(total)
+	for _, name := range WasteTypeNames {
+		pick -= WasteTypes[name].Weight
+		if pick < 0 {
+			return WasteTypes[name]
+		}
+	}
+	return FindWasteType("")
+}
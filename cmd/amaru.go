@@ -9,7 +9,9 @@ import (
 
 	"amaru/archetype"
 	"amaru/assets"
+	"amaru/component"
 	"amaru/engine"
+	"amaru/net"
 	"amaru/scene"
 )
 
@@ -27,6 +29,11 @@ func NewGame() *Game {
 	assets.MustLoadAssets()
 	archetype.MustLoadPlayerActions()
 
+	component.CurrentProfile = component.LoadProfile()
+	net.SetServer(component.CurrentProfile.Server)
+	archetype.ApplyVolumes(component.CurrentProfile)
+	component.CurrentStats = component.LoadStats()
+
 	g := &Game{
 		updateTicker: time.NewTicker(time.Second / 60),
 	}
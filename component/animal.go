@@ -1,16 +1,41 @@
 package component
 
 import (
+	"amaru/assets"
+
 	"github.com/jakecoffman/cp"
 	"github.com/yohamta/donburi"
 )
 
+// speeds, radius and rescue behaviour of each animal come from its species
+const (
+	// boats faster than this scare the animals within the flee radius,
+	// boats move at 256 pixels per second
+	FleeBoatSpeed = 160.0
+	FleeFrames    = 90
+	// animals wander around their spot of the map and never go past the leash
+	WanderRadius = 96.0
+	LeashRadius  = 192.0
+)
+
 type AnimalData struct {
+	Id        int
 	X         float64
 	Y         float64
 	Collected bool
 	Shape     *cp.Shape
 	Entry     *donburi.Entry
+	Species   *assets.AnimalSpecies
+	// the host moves the animals, peers follow the positions it sends
+	Home       cp.Vector
+	Target     cp.Vector
+	FleeFrom   cp.Vector
+	FleeFrames int
+	Entangled  bool
+	RescuedBy  string
 }
 
 var Animal = donburi.NewComponentType[AnimalData]()
+
+// EntangledTint greys out animals caught in waste.
+var EntangledTint = &ColorOverride{R: 0.6, G: 0.6, B: 0.6, A: 1, Tint: true}
@@ -0,0 +1,55 @@
+package component
+
+import (
+	"time"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// reported moves may exceed the max boat speed by this factor plus a fixed
+	// slack, updates arrive bunched together on a bad network
+	MoveSpeedTolerance = 1.25
+	MoveSlack          = 64.0
+	// how far inside an island a reported position may be before it is rejected
+	IslandDepth = 16.0
+	// max distance between a boat and the edge of what it picks up
+	PickupReach = 64.0
+	// pickups are rejected for this long after an impossible move
+	PickupGrace = time.Second
+
+	SuspectStrikes = 5
+	StrikeWindow   = 10 * time.Second
+)
+
+type MovementRecord struct {
+	Position cp.Vector
+	Time     time.Time
+}
+
+type Violations struct {
+	Strikes  int
+	Total    int
+	Last     time.Time
+	Reported bool
+}
+
+type AntiCheatData struct {
+	Enabled    bool
+	Islands    []*cp.Shape
+	Moves      map[string]*MovementRecord
+	Violations map[string]*Violations
+}
+
+var AntiCheat = donburi.NewComponentType[AntiCheatData]()
+
+func FindAntiCheat(w donburi.World) *AntiCheatData {
+	entry, ok := query.NewQuery(filter.Contains(AntiCheat)).First(w)
+	if !ok {
+		return nil
+	}
+	return AntiCheat.Get(entry)
+}
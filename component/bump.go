@@ -0,0 +1,23 @@
+package component
+
+const (
+	// frames before a boat can bump again
+	BumpCooldownFrames = 45
+	// boats closing slower than this only push each other apart, nobody is
+	// penalized
+	BumpMinSpeed = 25.0
+	// knockback given for each pixel per second of closing speed, it fades
+	// by BumpDecay every frame
+	BumpKnockback    = 1.4
+	BumpMinKnockback = 60.0
+	BumpMaxKnockback = 260.0
+	BumpDecay        = 0.9
+	// the aggressor bounces back with this share of the knockback
+	BumpRecoil = 0.5
+	// frames the bumped boat can not steer and both boats flash
+	BumpStunFrames  = 30
+	BumpFlashFrames = 24
+)
+
+// BumpFlash is the color boats flash with after a bump.
+var BumpFlash = &ColorOverride{R: 1, G: 0.35, B: 0.3, A: 1, Tint: true}
@@ -0,0 +1,73 @@
+package component
+
+import (
+	"amaru/assets"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// waste drifts slower than boats
+	WasteDriftFactor = 0.25
+)
+
+// CurrentsData holds the current zones of the level being played.
+type CurrentsData struct {
+	Zones []assets.Current
+}
+
+var Currents = donburi.NewComponentType[CurrentsData]()
+
+func FindCurrents(w donburi.World) *CurrentsData {
+	entry, ok := query.NewQuery(filter.Contains(Currents)).First(w)
+	if !ok {
+		return nil
+	}
+	return Currents.Get(entry)
+}
+
+// DriftAt returns the velocity, in pixels per second, the currents give to
+// a body at the position.
+func (c *CurrentsData) DriftAt(position cp.Vector) cp.Vector {
+	drift := cp.Vector{}
+	if c == nil {
+		return drift
+	}
+	point := math.Vec2{X: position.X, Y: position.Y}
+	for i := range c.Zones {
+		zone := &c.Zones[i]
+		if zone.Area.Contains(point) {
+			drift = drift.Add(cp.Vector{X: zone.Direction.X, Y: zone.Direction.Y}.Mult(zone.Strength))
+		}
+	}
+	return drift
+}
+
+// MaxStrength is the strongest drift of the level.
+func (c *CurrentsData) MaxStrength() float64 {
+	strength := 0.0
+	if c == nil {
+		return strength
+	}
+	for _, zone := range c.Zones {
+		if zone.Strength > strength {
+			strength = zone.Strength
+		}
+	}
+	return strength
+}
+
+// CurrentOverlayData animates the sprite drawn over a current zone, Mask is
+// opaque inside the zone and Phase moves the marks along the current.
+type CurrentOverlayData struct {
+	Zone  assets.Current
+	Mask  *ebiten.Image
+	Phase float64
+}
+
+var CurrentOverlay = donburi.NewComponentType[CurrentOverlayData]()
@@ -14,12 +14,7 @@ import (
 type SessionType int
 
 const (
-	MaxWasteSize          = 260
-	MinWasteSize          = 220
-	WastePoints           = 1
-	AnimalPoints          = 2
-	PlayerCollisionPoints = 2
-	MaxPlayers            = 4
+	MaxPlayers = 4
 
 	SessionTypeHost SessionType = iota
 	SessionTypeJoin
@@ -37,6 +32,7 @@ type GameData struct {
 	CollectedWaste   int
 	Dpad             *vpad.DirectionalPad
 	Muted            bool
+	Match            MatchData
 }
 
 type SessionData struct {
@@ -47,6 +43,10 @@ type SessionData struct {
 	UserName      *string
 	RemoteClient  *net.RemoteClient
 	PlayerMessage map[string]*RemotePlayerMessage
+	// rules picked by the host, peers use the ones in the game data
+	Rules net.RuleSet
+	// private sessions are advertised as locked
+	Private bool
 }
 
 type Settings struct {
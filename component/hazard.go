@@ -0,0 +1,80 @@
+package component
+
+import (
+	"amaru/assets"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// frames a boat stays under a hazard after it stops touching it
+	HazardContactFrames = 2
+	// frames after a stun before the same boat can be stunned again
+	ReefStunCooldown = 2 * 60
+	// radius around the local boat that stays visible in a storm
+	StormVisibility = 96.0
+)
+
+// StormData is the state of a storm zone, the host decides when it blows.
+type StormData struct {
+	Zone   assets.Storm
+	Active bool
+	Sprite *donburi.Entry
+}
+
+// HazardsData holds the hazards of the level being played.
+type HazardsData struct {
+	Whirlpools []*WhirlpoolData
+	Reefs      []assets.Reef
+	Storms     []*StormData
+}
+
+var Hazards = donburi.NewComponentType[HazardsData]()
+
+// WhirlpoolData animates the sprite of a whirlpool.
+type WhirlpoolData struct {
+	Zone   assets.Whirlpool
+	Frames []*ebiten.Image
+	Frame  int
+	Sprite *donburi.Entry
+}
+
+func FindHazards(w donburi.World) *HazardsData {
+	entry, ok := query.NewQuery(filter.Contains(Hazards)).First(w)
+	if !ok {
+		return nil
+	}
+	return Hazards.Get(entry)
+}
+
+// MaxPush is the fastest a hazard can carry a boat.
+func (h *HazardsData) MaxPush() float64 {
+	if h == nil {
+		return 0
+	}
+	whirlpools := 0.0
+	for _, whirlpool := range h.Whirlpools {
+		if strength := whirlpool.Zone.Pull + whirlpool.Zone.Spin; strength > whirlpools {
+			whirlpools = strength
+		}
+	}
+	storms := 0.0
+	for _, storm := range h.Storms {
+		if storm.Zone.Strength > storms {
+			storms = storm.Zone.Strength
+		}
+	}
+	return whirlpools + storms
+}
+
+// StormsActive lists which storms are blowing.
+func (h *HazardsData) StormsActive() []bool {
+	active := make([]bool, len(h.Storms))
+	for i, storm := range h.Storms {
+		active[i] = storm.Active
+	}
+	return active
+}
@@ -0,0 +1,59 @@
+package component
+
+const (
+	DefaultHull     = "dragon"
+	DefaultBoatTint = "natural"
+)
+
+// Hull changes how a boat moves and how far around it waste is picked up.
+type Hull struct {
+	Name  string
+	Label string
+	// multiplier of the boat speed
+	Speed float64
+	// share of the change of velocity applied each frame, 1 turns at once
+	Handling float64
+	// pixels around the boat where plain waste is picked up
+	Reach float64
+}
+
+var Hulls = []*Hull{
+	{Name: DefaultHull, Label: "Dragon: balanced", Speed: 1, Handling: 1},
+	{Name: "clipper", Label: "Clipper: fast, drifts", Speed: 1.25, Handling: 0.15},
+	{Name: "trawler", Label: "Trawler: slow, wide reach", Speed: 0.8, Handling: 0.5, Reach: 24},
+}
+
+// FindHull returns the hull with the name, unknown names get the default.
+func FindHull(name string) *Hull {
+	for _, hull := range Hulls {
+		if hull.Name == name {
+			return hull
+		}
+	}
+	return Hulls[0]
+}
+
+// BoatTint colors the boat sprite, team colors win in team rounds.
+type BoatTint struct {
+	Name  string
+	Label string
+	Tint  *ColorOverride
+}
+
+var BoatTints = []*BoatTint{
+	{Name: DefaultBoatTint, Label: "Natural"},
+	{Name: "coral", Label: "Coral", Tint: &ColorOverride{R: 1, G: 0.65, B: 0.55, A: 1, Tint: true}},
+	{Name: "jade", Label: "Jade", Tint: &ColorOverride{R: 0.6, G: 1, B: 0.7, A: 1, Tint: true}},
+	{Name: "violet", Label: "Violet", Tint: &ColorOverride{R: 0.8, G: 0.6, B: 1, A: 1, Tint: true}},
+	{Name: "sun", Label: "Sun", Tint: &ColorOverride{R: 1, G: 0.95, B: 0.5, A: 1, Tint: true}},
+}
+
+// FindBoatTint returns the tint with the name, unknown names get the default.
+func FindBoatTint(name string) *BoatTint {
+	for _, tint := range BoatTints {
+		if tint.Name == name {
+			return tint
+		}
+	}
+	return BoatTints[0]
+}
@@ -10,6 +10,10 @@ const (
 	BoxCollisionType
 	WasteCollisionType
 	AnimalCollisionType
+	PowerUpCollisionType
+	WhirlpoolCollisionType
+	ReefCollisionType
+	StormCollisionType
 )
 
 type PhysicsData struct {
@@ -1,9 +1,9 @@
 package component
 
 import (
+	"amaru/assets"
 	"amaru/net"
 	"image/color"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/jakecoffman/cp"
@@ -36,19 +36,41 @@ type RemotePlayerMessage struct {
 }
 
 type PlayerData struct {
-	ID                  string
-	Name                string
-	Local               bool
-	Body                *cp.Body
-	Shape               *cp.Shape
-	Space               *cp.Space
-	PlayerSettings      *PlayerSettings
-	Collision           bool
-	PlayerCollision     bool
-	LastPlayerCollision *time.Time
-	LastDirection       *cp.Vector
-	Label               *donburi.Entry
-	OutOfBounds         bool
+	ID             string
+	Name           string
+	Local          bool
+	Body           *cp.Body
+	Shape          *cp.Shape
+	Space          *cp.Space
+	PlayerSettings *PlayerSettings
+	Collision      bool
+	LastDirection  *cp.Vector
+	Label          *donburi.Entry
+	OutOfBounds    bool
+	// nets slow the boat down for a few frames
+	SlowFrames int
+	SlowFactor float64
+	Slowed     bool
+	// frames left of the active power-ups by kind
+	PowerUps map[string]int
+	Boosted  bool
+	// hull and color picked by the player
+	Hull *Hull
+	Tint *BoatTint
+	// hazards touched in the last physics step
+	Whirlpool       *assets.Whirlpool
+	WhirlpoolFrames int
+	Storm           *StormData
+	StormFrames     int
+	// reefs stop the boat for a while, and can't stop it again right away
+	StunFrames   int
+	StunCooldown int
+	Stunned      bool
+	// bumps push the boat for a while, it fades every frame
+	Knockback    cp.Vector
+	Knocked      bool
+	BumpCooldown int
+	BumpFrames   int
 }
 
 var Player = donburi.NewComponentType[PlayerData]()
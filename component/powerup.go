@@ -0,0 +1,68 @@
+package component
+
+import (
+	"image/color"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"golang.org/x/image/colornames"
+)
+
+const (
+	PowerUpSpeed  = "speed"
+	PowerUpMagnet = "magnet"
+	PowerUpShield = "shield"
+	PowerUpNet    = "net"
+
+	// seconds between spawns and the most power-ups waiting in the water
+	PowerUpInterval = 8
+	MaxPowerUps     = 3
+
+	// boosted boats move this much faster
+	SpeedBoost = 1.6
+	// magnets pull the waste in range at this many pixels per second
+	MagnetRadius = 160.0
+	MagnetSpeed  = 90.0
+	// nets collect the waste in range while they last
+	NetRadius = 72.0
+)
+
+// PowerUpKinds lists the power-ups the host picks from.
+var PowerUpKinds = []string{PowerUpSpeed, PowerUpMagnet, PowerUpShield, PowerUpNet}
+
+// PowerUpFrames is how long each power-up lasts once picked up.
+var PowerUpFrames = map[string]int{
+	PowerUpSpeed:  6 * 60,
+	PowerUpMagnet: 8 * 60,
+	PowerUpShield: 10 * 60,
+	PowerUpNet:    5 * 60,
+}
+
+var PowerUpLabels = map[string]string{
+	PowerUpSpeed:  "Speed",
+	PowerUpMagnet: "Magnet",
+	PowerUpShield: "Shield",
+	PowerUpNet:    "Net",
+}
+
+var PowerUpColors = map[string]color.Color{
+	PowerUpSpeed:  colornames.Gold,
+	PowerUpMagnet: colornames.Orangered,
+	PowerUpShield: colornames.Deepskyblue,
+	PowerUpNet:    colornames.Limegreen,
+}
+
+type PowerUpData struct {
+	Id        string
+	Kind      string
+	Collected bool
+	Shape     *cp.Shape
+	Entry     *donburi.Entry
+}
+
+var PowerUp = donburi.NewComponentType[PowerUpData]()
+
+// HasPowerUp tells if the boat has the power-up active.
+func (p *PlayerData) HasPowerUp(kind string) bool {
+	return p.PowerUps[kind] > 0
+}
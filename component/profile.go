@@ -0,0 +1,110 @@
+package component
+
+import (
+	"amaru/engine"
+	"amaru/net"
+	"encoding/json"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"golang.org/x/image/colornames"
+)
+
+const (
+	ProfileKey = "profile"
+
+	DefaultBoatColor = "#ffa500"
+)
+
+type Volumes struct {
+	Music   float64
+	Effects float64
+}
+
+// Profile holds the player preferences that survive restarts.
+type Profile struct {
+	Name      string
+	BoatColor string
+	Volumes   Volumes
+	Muted     bool
+	Inputs    PlayerInputs
+	Server    string
+	// preset used for the sessions this player hosts
+	RuleSet string
+	Mode    string
+	Private bool
+	// boat picked before hosting or joining
+	Hull string
+	Tint string
+	// updates per second sent to the other players
+	SendRate int
+}
+
+// CurrentProfile is loaded at startup, changes are written back with Save.
+var CurrentProfile = DefaultProfile()
+
+func DefaultProfile() *Profile {
+	return &Profile{
+		BoatColor: DefaultBoatColor,
+		Volumes: Volumes{
+			Music:   1,
+			Effects: 1,
+		},
+		Inputs: PlayerInputs{
+			Up:    ebiten.KeyUp,
+			Right: ebiten.KeyRight,
+			Down:  ebiten.KeyDown,
+			Left:  ebiten.KeyLeft,
+			Shoot: ebiten.KeyEnter,
+		},
+		Server:   net.DefaultServer,
+		RuleSet:  net.RuleSetStandard,
+		Mode:     net.SessionModeClassic,
+		Hull:     DefaultHull,
+		Tint:     DefaultBoatTint,
+		SendRate: net.DefaultSendRate,
+	}
+}
+
+// LoadProfile reads the stored profile, missing or broken values fall back to
+// the defaults.
+func LoadProfile() *Profile {
+	profile := DefaultProfile()
+	data, err := engine.ReadStorage(ProfileKey)
+	if err != nil {
+		return profile
+	}
+	if err := json.Unmarshal(data, profile); err != nil {
+		fmt.Println(err)
+		return DefaultProfile()
+	}
+	return profile
+}
+
+func (p *Profile) Save() {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := engine.WriteStorage(ProfileKey, data); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// UserName returns the stored name, nil when none was entered yet.
+func (p *Profile) UserName() *string {
+	if p.Name == "" {
+		return nil
+	}
+	return engine.Ptr(p.Name)
+}
+
+func (p *Profile) Color() color.Color {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(p.BoatColor, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return colornames.Orange
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
@@ -47,6 +47,8 @@ type UISpriteData struct {
 
 type ColorOverride struct {
 	R, G, B, A float64
+	// Tint multiplies the sprite colors instead of replacing them
+	Tint bool
 }
 
 func (s *SpriteData) Show() {
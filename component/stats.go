@@ -0,0 +1,88 @@
+package component
+
+import (
+	"amaru/engine"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	StatsKey = "stats"
+	// older matches are dropped from the history, lifetime totals keep them
+	MaxHistory = 50
+)
+
+// MatchData counts what the local player did during the current match.
+type MatchData struct {
+	Started    time.Time
+	Waste      int
+	Animals    int
+	Collisions int
+}
+
+type MatchRecord struct {
+	Date       time.Time
+	Level      int
+	Duration   time.Duration
+	Score      int
+	Waste      int
+	Animals    int
+	Collisions int
+	Rank       int
+	Players    int
+	Opponents  []string
+}
+
+// Stats holds the lifetime totals and the most recent matches, newest first.
+type Stats struct {
+	Matches      int
+	Wins         int
+	BestScore    int
+	TotalWaste   int
+	TotalAnimals int
+	History      []MatchRecord
+}
+
+var CurrentStats = &Stats{}
+
+func LoadStats() *Stats {
+	stats := &Stats{}
+	data, err := engine.ReadStorage(StatsKey)
+	if err != nil {
+		return stats
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		fmt.Println(err)
+		return &Stats{}
+	}
+	return stats
+}
+
+func (s *Stats) Save() {
+	data, err := json.Marshal(s)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := engine.WriteStorage(StatsKey, data); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// Record adds a finished match to the history and the lifetime totals.
+func (s *Stats) Record(record MatchRecord) {
+	s.Matches++
+	if record.Rank == 1 && record.Score > 0 {
+		s.Wins++
+	}
+	if record.Score > s.BestScore {
+		s.BestScore = record.Score
+	}
+	s.TotalWaste += record.Waste
+	s.TotalAnimals += record.Animals
+	s.History = append([]MatchRecord{record}, s.History...)
+	if len(s.History) > MaxHistory {
+		s.History = s.History[:MaxHistory]
+	}
+}
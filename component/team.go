@@ -0,0 +1,20 @@
+package component
+
+import (
+	"amaru/net"
+	"image/color"
+
+	"golang.org/x/image/colornames"
+)
+
+// TeamColors are used for the name labels and the results of each team.
+var TeamColors = map[int]color.Color{
+	net.TeamRed:  colornames.Tomato,
+	net.TeamBlue: colornames.Deepskyblue,
+}
+
+// TeamTints are applied to the boats of each team.
+var TeamTints = map[int]*ColorOverride{
+	net.TeamRed:  {R: 1, G: 0.55, B: 0.55, A: 1, Tint: true},
+	net.TeamBlue: {R: 0.55, G: 0.8, B: 1, A: 1, Tint: true},
+}
@@ -2,17 +2,39 @@ package component
 
 import (
 	"amaru/assets"
+	"time"
 
 	"github.com/jakecoffman/cp"
 	"github.com/yohamta/donburi"
 )
 
+const (
+	// boats count as helping with heavy waste for this long after touching it
+	WasteHelperWindow = 1500 * time.Millisecond
+)
+
 type WasteData struct {
 	Id        string
+	Type      *assets.WasteType
 	Path      assets.Path
 	Collected bool
 	Shape     *cp.Shape
 	Entry     *donburi.Entry
+	// boats that touched heavy waste, by participant id
+	Helpers map[string]*WasteHelper
+	// boats going around an oil slick, by participant id
+	Circles map[string]*WasteCircle
+}
+
+type WasteHelper struct {
+	Player *PlayerData
+	Last   time.Time
+}
+
+// WasteCircle follows the angle a boat swept around the waste center.
+type WasteCircle struct {
+	LastAngle float64
+	Swept     float64
 }
 
 var Waste = donburi.NewComponentType[WasteData]()
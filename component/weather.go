@@ -0,0 +1,146 @@
+package component
+
+import (
+	"amaru/net"
+	"math"
+	"math/rand"
+
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// hours of the day a round goes through
+	WeatherRoundHours = 6.0
+	// rain drops drawn when it rains the hardest
+	WeatherDrops = 240
+	// how far the boat lights reach at night
+	BoatLightRadius = 40.0
+	BoatLightLength = 180.0
+)
+
+// WeatherDrop is a rain drop, its position is a share of the screen.
+type WeatherDrop struct {
+	X     float64
+	Y     float64
+	Speed float64
+}
+
+// WeatherBank is a fog bank, Size scales it to the screen height.
+type WeatherBank struct {
+	X    float64
+	Y    float64
+	Size float64
+}
+
+// WeatherData is the weather of a round. Everything comes from the seed the
+// host sends, so every peer sees the same sky at the same time of the round.
+type WeatherData struct {
+	Seed int64
+	// hour of the day the round starts at
+	StartHour float64
+	// share of the round when the rain starts and stops, and how hard it gets
+	RainFrom float64
+	RainTo   float64
+	Rain     float64
+	Fog      float64
+	Wind     float64
+	Drops    []WeatherDrop
+	Banks    []WeatherBank
+}
+
+var Weather = donburi.NewComponentType[WeatherData]()
+
+func FindWeather(w donburi.World) *WeatherData {
+	entry, ok := query.NewQuery(filter.Contains(Weather)).First(w)
+	if !ok {
+		return nil
+	}
+	return Weather.Get(entry)
+}
+
+// NewWeather rolls the weather of a round from the seed.
+func NewWeather(seed int64) WeatherData {
+	random := rand.New(rand.NewSource(seed))
+	weather := WeatherData{
+		Seed:      seed,
+		StartHour: random.Float64() * 24,
+		Wind:      random.Float64()*2 - 1,
+	}
+	// it rains in about a third of the rounds and fog covers a fourth
+	if random.Float64() < 0.35 {
+		weather.RainFrom = random.Float64() * 0.5
+		weather.RainTo = weather.RainFrom + 0.3 + random.Float64()*0.5
+		weather.Rain = 0.4 + random.Float64()*0.6
+	}
+	if random.Float64() < 0.25 {
+		weather.Fog = 0.2 + random.Float64()*0.3
+	}
+	for i := 0; i < WeatherDrops; i++ {
+		weather.Drops = append(weather.Drops, WeatherDrop{
+			X:     random.Float64(),
+			Y:     random.Float64(),
+			Speed: 0.8 + random.Float64()*0.4,
+		})
+	}
+	for i := 0; i < 12; i++ {
+		weather.Banks = append(weather.Banks, WeatherBank{
+			X:    random.Float64(),
+			Y:    random.Float64(),
+			Size: 0.5 + random.Float64(),
+		})
+	}
+	return weather
+}
+
+// RoundProgress is the share of the round already played.
+func RoundProgress(gameData *net.GameData) float64 {
+	if gameData == nil || gameData.Rules.RoundSeconds <= 0 {
+		return 0
+	}
+	elapsed := float64(gameData.Rules.RoundSeconds-gameData.Counter) + float64(gameData.Frames%60)/60
+	return math.Max(0, math.Min(elapsed/float64(gameData.Rules.RoundSeconds), 1))
+}
+
+// Hour is the time of the day at the progress of the round.
+func (w *WeatherData) Hour(progress float64) float64 {
+	return math.Mod(w.StartHour+progress*WeatherRoundHours, 24)
+}
+
+// Daylight goes from 0 at one in the morning to 1 at one in the afternoon.
+func (w *WeatherData) Daylight(progress float64) float64 {
+	return 0.5 + 0.5*math.Cos((w.Hour(progress)-13)*math.Pi/12)
+}
+
+// Darkness is how much of the night covers the sea, boats light it up.
+func (w *WeatherData) Darkness(progress float64) float64 {
+	return math.Max(0, (0.45-w.Daylight(progress))/0.45) * 0.8
+}
+
+// RainAt is how hard it rains at the progress of the round, it fades in and
+// out.
+func (w *WeatherData) RainAt(progress float64) float64 {
+	if w.Rain == 0 || progress < w.RainFrom || progress > w.RainTo {
+		return 0
+	}
+	fade := math.Min(progress-w.RainFrom, w.RainTo-progress) / 0.1
+	return w.Rain * math.Min(fade, 1)
+}
+
+// Tint is the color of the light, golden at dawn and dusk and blue at night,
+// rain makes it greyer.
+func (w *WeatherData) Tint(progress float64) (float64, float64, float64) {
+	night := [3]float64{0.55, 0.6, 0.9}
+	golden := [3]float64{1, 0.8, 0.62}
+	day := [3]float64{1, 1, 1}
+	light := w.Daylight(progress)
+	from, to, t := night, golden, light/0.6
+	if light >= 0.6 {
+		from, to, t = golden, day, (light-0.6)/0.4
+	}
+	grey := 1 - 0.2*w.RainAt(progress)
+	return (from[0] + (to[0]-from[0])*t) * grey,
+		(from[1] + (to[1]-from[1])*t) * grey,
+		(from[2] + (to[2]-from[2])*t) * grey
+}
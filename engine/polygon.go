@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/jakecoffman/cp"
+)
+
+// tolerance for repeated points and straight corners
+const polygonEpsilon = 1e-6
+
+// ConvexDecompose splits a simple polygon into convex polygons. It clips the
+// ears of the polygon into triangles and merges the triangles back while the
+// result stays convex. The pieces always have a positive winding, polygons
+// without area have no pieces.
+func ConvexDecompose(points []cp.Vector) [][]cp.Vector {
+	points = cleanPolygon(points)
+	if len(points) < 3 || math.Abs(polygonArea(points)) < polygonEpsilon {
+		return nil
+	}
+	if polygonArea(points) < 0 {
+		reversed := make([]cp.Vector, len(points))
+		for i, point := range points {
+			reversed[len(points)-1-i] = point
+		}
+		points = reversed
+	}
+	if isConvex(points) {
+		return [][]cp.Vector{points}
+	}
+
+	pieces := mergeConvex(points, triangulate(points))
+	polygons := make([][]cp.Vector, 0, len(pieces))
+	for _, piece := range pieces {
+		polygons = append(polygons, indexed(points, piece))
+	}
+	return polygons
+}
+
+// cleanPolygon drops repeated points, including a last point closing the
+// polygon on the first one.
+func cleanPolygon(points []cp.Vector) []cp.Vector {
+	cleaned := make([]cp.Vector, 0, len(points))
+	for _, point := range points {
+		if len(cleaned) > 0 && cleaned[len(cleaned)-1].Near(point, polygonEpsilon) {
+			continue
+		}
+		cleaned = append(cleaned, point)
+	}
+	for len(cleaned) > 1 && cleaned[0].Near(cleaned[len(cleaned)-1], polygonEpsilon) {
+		cleaned = cleaned[:len(cleaned)-1]
+	}
+	return cleaned
+}
+
+func polygonArea(points []cp.Vector) float64 {
+	area := 0.0
+	for i := range points {
+		area += points[i].Cross(points[(i+1)%len(points)])
+	}
+	return area / 2
+}
+
+func isConvex(points []cp.Vector) bool {
+	for i := range points {
+		a, b, c := points[i], points[(i+1)%len(points)], points[(i+2)%len(points)]
+		if b.Sub(a).Cross(c.Sub(b)) < -polygonEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// triangulate clips the ears of a polygon with positive winding, it returns
+// the triangles as indexes of the points.
+func triangulate(points []cp.Vector) [][]int {
+	remaining := make([]int, len(points))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	triangles := [][]int{}
+	for len(remaining) > 3 {
+		clipped := false
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			current := remaining[i]
+			next := remaining[(i+1)%len(remaining)]
+			if !isEar(points, remaining, prev, current, next) {
+				continue
+			}
+			triangles = append(triangles, []int{prev, current, next})
+			remaining = append(remaining[:i:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+		// self intersecting polygons run out of ears, keep what is left
+		if !clipped {
+			break
+		}
+	}
+	return append(triangles, remaining)
+}
+
+func isEar(points []cp.Vector, remaining []int, prev, current, next int) bool {
+	a, b, c := points[prev], points[current], points[next]
+	if b.Sub(a).Cross(c.Sub(b)) <= polygonEpsilon {
+		return false
+	}
+	for _, index := range remaining {
+		if index == prev || index == current || index == next {
+			continue
+		}
+		if insideTriangle(points[index], a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func insideTriangle(point, a, b, c cp.Vector) bool {
+	return b.Sub(a).Cross(point.Sub(a)) >= 0 && c.Sub(b).Cross(point.Sub(b)) >= 0 && a.Sub(c).Cross(point.Sub(c)) >= 0
+}
+
+// mergeConvex joins pieces sharing an edge as long as the joined piece is
+// still convex.
+func mergeConvex(points []cp.Vector, pieces [][]int) [][]int {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				joined := joinPieces(pieces[i], pieces[j])
+				if joined == nil || !isConvex(indexed(points, joined)) {
+					continue
+				}
+				pieces[i] = joined
+				pieces = append(pieces[:j], pieces[j+1:]...)
+				merged = true
+			}
+		}
+	}
+	return pieces
+}
+
+// joinPieces returns the piece made of two pieces with a shared edge, or nil
+// when they do not share one.
+func joinPieces(one, other []int) []int {
+	for i := range one {
+		a, b := one[i], one[(i+1)%len(one)]
+		for j := range other {
+			// the shared edge goes the other way around in the other piece
+			if other[j] != b || other[(j+1)%len(other)] != a {
+				continue
+			}
+			joined := make([]int, 0, len(one)+len(other)-2)
+			for k := 0; k < len(one); k++ {
+				joined = append(joined, one[(i+1+k)%len(one)])
+			}
+			for k := 2; k < len(other); k++ {
+				joined = append(joined, other[(j+k)%len(other)])
+			}
+			return joined
+		}
+	}
+	return nil
+}
+
+func indexed(points []cp.Vector, indexes []int) []cp.Vector {
+	polygon := make([]cp.Vector, len(indexes))
+	for i, index := range indexes {
+		polygon[i] = points[index]
+	}
+	return polygon
+}
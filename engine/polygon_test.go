@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jakecoffman/cp"
+)
+
+func polygon(coords ...float64) []cp.Vector {
+	points := make([]cp.Vector, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		points = append(points, cp.Vector{X: coords[i], Y: coords[i+1]})
+	}
+	return points
+}
+
+func reversedPolygon(points []cp.Vector) []cp.Vector {
+	reversed := make([]cp.Vector, len(points))
+	for i, point := range points {
+		reversed[len(points)-1-i] = point
+	}
+	return reversed
+}
+
+var (
+	square = polygon(0, 0, 10, 0, 10, 10, 0, 10)
+	lShape = polygon(0, 0, 20, 0, 20, 10, 10, 10, 10, 20, 0, 20)
+	// island 4 of level 1
+	island = polygon(0, 0, 216, 0, 216, 186, 152, 186, 152, 248, 0, 248, 0, 194, 66, 194, 66, 122, 0, 122)
+)
+
+// checkPieces tells if every piece is convex with a positive winding and the
+// pieces cover the area of the polygon.
+func checkPieces(t *testing.T, points []cp.Vector, pieces [][]cp.Vector) {
+	t.Helper()
+	covered := 0.0
+	for _, piece := range pieces {
+		if len(piece) < 3 {
+			t.Fatalf("piece with %d points", len(piece))
+		}
+		if !isConvex(piece) {
+			t.Fatalf("piece %v is not convex", piece)
+		}
+		area := polygonArea(piece)
+		if area <= 0 {
+			t.Fatalf("piece %v has area %f", piece, area)
+		}
+		covered += area
+	}
+	if want := math.Abs(polygonArea(points)); math.Abs(covered-want) > polygonEpsilon {
+		t.Fatalf("pieces cover %f, polygon area is %f", covered, want)
+	}
+}
+
+func TestConvexDecomposeConvex(t *testing.T) {
+	pieces := ConvexDecompose(square)
+	if len(pieces) != 1 {
+		t.Fatalf("expected 1 piece, got %d", len(pieces))
+	}
+	for i, point := range square {
+		if pieces[0][i] != point {
+			t.Fatalf("expected the square unchanged, got %v", pieces[0])
+		}
+	}
+}
+
+func TestConvexDecomposeConcave(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []cp.Vector
+	}{
+		{"l shape", lShape},
+		{"l shape clockwise", reversedPolygon(lShape)},
+		{"island", island},
+		{"island clockwise", reversedPolygon(island)},
+		{"closed on the first point", append(append([]cp.Vector{}, lShape...), lShape[0])},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pieces := ConvexDecompose(test.points)
+			if len(pieces) < 2 {
+				t.Fatalf("expected a concave polygon to split, got %d pieces", len(pieces))
+			}
+			checkPieces(t, test.points, pieces)
+		})
+	}
+}
+
+func TestConvexDecomposeWinding(t *testing.T) {
+	counterClockwise := ConvexDecompose(lShape)
+	clockwise := ConvexDecompose(reversedPolygon(lShape))
+	if len(counterClockwise) != len(clockwise) {
+		t.Fatalf("expected the same pieces for both windings, got %d and %d", len(counterClockwise), len(clockwise))
+	}
+	pieces := ConvexDecompose(reversedPolygon(square))
+	if len(pieces) != 1 || polygonArea(pieces[0]) <= 0 {
+		t.Fatalf("expected a clockwise square as one piece with a positive winding, got %v", pieces)
+	}
+}
+
+func TestConvexDecomposeDegenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []cp.Vector
+	}{
+		{"empty", nil},
+		{"one point", polygon(1, 1)},
+		{"two points", polygon(0, 0, 10, 10)},
+		{"repeated points", polygon(5, 5, 5, 5, 5, 5, 5, 5)},
+		{"collinear", polygon(0, 0, 5, 0, 10, 0)},
+		{"collinear back and forth", polygon(0, 0, 10, 0, 5, 0, 20, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if pieces := ConvexDecompose(test.points); pieces != nil {
+				t.Fatalf("expected no pieces, got %v", pieces)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+//go:build !js
+// +build !js
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const (
+	storageDir = "amaru"
+)
+
+func storagePath(key string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, storageDir, key+".json"), nil
+}
+
+// ReadStorage reads a value saved with WriteStorage, on desktop values are
+// files in the user config dir.
+func ReadStorage(key string) ([]byte, error) {
+	path, err := storagePath(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+func WriteStorage(key string, data []byte) error {
+	path, err := storagePath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
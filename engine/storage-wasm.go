@@ -0,0 +1,46 @@
+//go:build js
+// +build js
+
+package engine
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+const (
+	storagePrefix = "amaru-"
+)
+
+var ErrStorageUnavailable = errors.New("storage: localStorage is not available")
+
+func localStorage() (js.Value, error) {
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return js.Value{}, ErrStorageUnavailable
+	}
+	return storage, nil
+}
+
+// ReadStorage reads a value saved with WriteStorage, in the browser values
+// live in localStorage.
+func ReadStorage(key string) ([]byte, error) {
+	storage, err := localStorage()
+	if err != nil {
+		return nil, err
+	}
+	value := storage.Call("getItem", storagePrefix+key)
+	if value.IsNull() {
+		return nil, errors.New("storage: " + key + " not found")
+	}
+	return []byte(value.String()), nil
+}
+
+func WriteStorage(key string, data []byte) error {
+	storage, err := localStorage()
+	if err != nil {
+		return err
+	}
+	storage.Call("setItem", storagePrefix+key, string(data))
+	return nil
+}
@@ -0,0 +1,113 @@
+package net
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/nmorenor/chezmoi-net/client"
+	cnet "github.com/nmorenor/chezmoi-net/net"
+)
+
+// NewSocket opens the hub connection that fits the platform, browsers can only
+// use web sockets.
+func NewSocket() cnet.ISocket {
+	if runtime.GOOS == "js" {
+		return cnet.NewWebSocket(WebSocketConnectionURL)
+	}
+	return cnet.NewKCPSocket(ConnectionURL, KCPKey)
+}
+
+// advertiser keeps the session advert of a host up to date. The hub lists a
+// session with the name it was hosted with and has no way to rename it, so
+// each new advert is hosted as a listing of its own on a second connection.
+// The listing points to the real session, GetAvailableSessions merges both and
+// never shows the listing. Closing a connection closes its listing on the hub,
+// so at most one listing is up once a new one replaced the previous.
+type advertiser struct {
+	mutex    *sync.Mutex
+	current  SessionAdvert
+	sequence int
+	// latest listing asked for, and the one currently hosted
+	listing *client.Client
+	hosted  *client.Client
+	closed  bool
+}
+
+func newAdvertiser() *advertiser {
+	return &advertiser{
+		mutex: &sync.Mutex{},
+	}
+}
+
+// published records the advert the session was hosted with.
+func (a *advertiser) published(advert SessionAdvert) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.current = advert
+}
+
+// publish hosts a listing of session with the new advert and closes the
+// previous listing once the new one is up, it does nothing when the advert did
+// not change.
+func (a *advertiser) publish(name string, session string, advert SessionAdvert) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.closed || advert == a.current {
+		return
+	}
+	a.current = advert
+	a.sequence++
+	advert.Session = session
+	advert.Sequence = a.sequence
+
+	listing := client.NewClient(NewSocket())
+	listing.OnConnect = func() {
+		if !a.latest(listing) {
+			listing.Close()
+			return
+		}
+		listing.StartHosting(EncodeSessionAdvert(name, advert))
+		a.hosting(listing)
+	}
+	// nobody else waits for the listing to disconnect
+	go func() {
+		<-listing.Interrupt
+	}()
+	// a listing still connecting closes itself once it is up, see latest
+	a.listing = listing
+	go listing.Connect()
+}
+
+func (a *advertiser) latest(listing *client.Client) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return !a.closed && a.listing == listing
+}
+
+// hosting swaps the hosted listing, a listing that was replaced or closed
+// while it was being hosted is closed right away.
+func (a *advertiser) hosting(listing *client.Client) {
+	a.mutex.Lock()
+	if a.closed || a.listing != listing {
+		a.mutex.Unlock()
+		listing.Close()
+		return
+	}
+	previous := a.hosted
+	a.hosted = listing
+	a.mutex.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+}
+
+func (a *advertiser) close() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.closed = true
+	if a.hosted != nil {
+		a.hosted.Close()
+	}
+	a.listing = nil
+	a.hosted = nil
+}
@@ -0,0 +1,54 @@
+package net
+
+// AnimalState is the part of an animal the host replicates, Id is the index
+// of the animal in the level.
+type AnimalState struct {
+	Id        int
+	Position  Point
+	Entangled bool
+	Collected bool
+	RescuedBy string
+}
+
+type AnimalStatesMessage struct {
+	Source  string
+	Animals []AnimalState
+}
+
+type RemoteAnimalStatesMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    AnimalStatesMessage
+}
+
+func (remoteClient *RemoteClient) SendAnimalStatesMessage(animals []AnimalState) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnAnimalStates",
+		payload: &AnimalStatesMessage{
+			Source:  *remoteClient.Client.Id,
+			Animals: animals,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnAnimalStates(message *AnimalStatesMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		// every update carries the whole state, a lost one is replaced by the next
+		remoteClient.receive(msg.Source, true, func() {
+			remoteClient.RemoteAnimalStates.Emit(remoteClient.ctx, RemoteAnimalStatesMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
@@ -4,13 +4,176 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ProtocolVersion changes whenever peers of different builds can no
+	// longer play together.
+	ProtocolVersion = 4
+
+	SessionModeClassic  = "classic"
+	SessionModeTeams    = "teams"
+	SessionModeCoop     = "coop"
+	SessionPhasePlaying = "playing"
+	SessionPhaseBreak   = "break"
+
+	// the hub only lists the host name, so hosts append their advert to it
+	advertSeparator = "|"
 )
 
 type AvailableSession struct {
 	ID              string `json:"id"`
 	SessionHostName string `json:"name"`
 	Size            int    `json:"size"`
+	Level           int    `json:"level"`
+	Mode            string `json:"mode"`
+	Phase           string `json:"phase"`
+	Locked          bool   `json:"locked"`
+	Version         int    `json:"version"`
+	// hub round trip of a member query for the session, zero when unknown
+	Ping time.Duration `json:"-"`
+}
+
+// Compatible tells if this build can join the session.
+func (s AvailableSession) Compatible() bool {
+	return s.Version == ProtocolVersion
+}
+
+// FasterThan tells if the session answered the ping probe sooner than the
+// other one, sessions that were not probed come last.
+func (s AvailableSession) FasterThan(other AvailableSession) bool {
+	if s.Ping == 0 || other.Ping == 0 {
+		return s.Ping != 0
+	}
+	return s.Ping < other.Ping
+}
+
+// SessionAdvert is the session metadata a host publishes when it starts
+// hosting. Later adverts are listings of their own that name the session they
+// describe and a sequence, so the newest one wins. Listings are never shown
+// as sessions.
+type SessionAdvert struct {
+	Level    int
+	Mode     string
+	Phase    string
+	Locked   bool
+	Version  int
+	Session  string
+	Sequence int
+}
+
+// EncodeSessionAdvert appends the advert to the host name, as in
+// "name|v=1;l=2;m=classic;p=playing;k=0". Listings add ";s=<session>;n=2" and
+// move the version to "r", a "v=0" makes every build that reads adverts see
+// them as incompatible rooms nobody can join.
+func EncodeSessionAdvert(name string, advert SessionAdvert) string {
+	locked := 0
+	if advert.Locked {
+		locked = 1
+	}
+	if advert.Session != "" {
+		return fmt.Sprintf("%s%sv=0;l=%d;m=%s;p=%s;k=%d;s=%s;n=%d;r=%d", name, advertSeparator, advert.Level, advert.Mode, advert.Phase, locked, advert.Session, advert.Sequence, advert.Version)
+	}
+	return fmt.Sprintf("%s%sv=%d;l=%d;m=%s;p=%s;k=%d", name, advertSeparator, advert.Version, advert.Level, advert.Mode, advert.Phase, locked)
+}
+
+// DecodeSessionAdvert splits a host name into the plain name and its advert,
+// names without a valid advert are returned as is.
+func DecodeSessionAdvert(name string) (string, SessionAdvert, bool) {
+	advert := SessionAdvert{}
+	listed := 0
+	index := strings.LastIndex(name, advertSeparator)
+	if index < 0 {
+		return name, advert, false
+	}
+	for _, field := range strings.Split(name[index+1:], ";") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return name, SessionAdvert{}, false
+		}
+		var err error
+		switch key {
+		case "v":
+			advert.Version, err = strconv.Atoi(value)
+		case "l":
+			advert.Level, err = strconv.Atoi(value)
+		case "m":
+			advert.Mode = value
+		case "p":
+			advert.Phase = value
+		case "k":
+			advert.Locked = value == "1"
+		case "s":
+			advert.Session = value
+		case "n":
+			advert.Sequence, err = strconv.Atoi(value)
+		case "r":
+			listed, err = strconv.Atoi(value)
+		}
+		if err != nil {
+			return name, SessionAdvert{}, false
+		}
+	}
+	if advert.Session != "" {
+		advert.Version = listed
+	}
+	if advert.Version == 0 {
+		return name, SessionAdvert{}, false
+	}
+	return name[:index], advert, true
+}
+
+// PlainName strips the session advert from a participant name.
+func PlainName(name *string) *string {
+	if name == nil {
+		return nil
+	}
+	plain, _, _ := DecodeSessionAdvert(*name)
+	return &plain
+}
+
+func plainNames(members map[string]*string) map[string]*string {
+	plain := make(map[string]*string, len(members))
+	for id, name := range members {
+		plain[id] = PlainName(name)
+	}
+	return plain
+}
+
+// pingStep is the ping difference Quick Play tells apart, sessions closer
+// than that are ranked by players
+const pingStep = 25 * time.Millisecond
+
+// pingRank buckets the ping of the session, sessions that were not probed
+// rank last.
+func pingRank(session AvailableSession) time.Duration {
+	if session.Ping == 0 {
+		return math.MaxInt64
+	}
+	return session.Ping / pingStep
+}
+
+// BestSession picks the session Quick Play joins: a compatible, unlocked
+// session with room left, preferring the lowest ping and then the most
+// players. It returns nil when there is none.
+func BestSession(sessions []AvailableSession, maxPlayers int) *AvailableSession {
+	var best *AvailableSession
+	for i := range sessions {
+		session := &sessions[i]
+		if !session.Compatible() || session.Locked || session.Size >= maxPlayers {
+			continue
+		}
+		if best == nil || pingRank(*session) < pingRank(*best) ||
+			(pingRank(*session) == pingRank(*best) && session.Size > best.Size) {
+			best = session
+		}
+	}
+	return best
 }
 
 func GetAvailableSessions() *[]AvailableSession {
@@ -26,12 +189,58 @@ func GetAvailableSessions() *[]AvailableSession {
 		fmt.Println(err)
 		return nil
 	}
-
-	var sessions []AvailableSession
-	err = json.Unmarshal(body, &sessions)
+	var listed []AvailableSession
+	err = json.Unmarshal(body, &listed)
 	if err != nil {
 		fmt.Println(err)
 		return nil
 	}
+	sessions := mergeAdverts(listed)
+	probeSessions(sessions)
 	return &sessions
 }
+
+// mergeAdverts decodes the advert of every listed session and applies the
+// newest advert listing to the session it describes, the listings themselves
+// are not sessions anyone can join.
+func mergeAdverts(listed []AvailableSession) []AvailableSession {
+	adverts := map[string]SessionAdvert{}
+	sessions := make([]AvailableSession, 0, len(listed))
+	for _, session := range listed {
+		name, advert, ok := DecodeSessionAdvert(session.SessionHostName)
+		if ok && advert.Session != "" {
+			if current, found := adverts[advert.Session]; !found || advert.Sequence > current.Sequence {
+				adverts[advert.Session] = advert
+			}
+			continue
+		}
+		if ok {
+			session.SessionHostName = name
+			// a listing seen before keeps precedence over the hosted advert
+			if _, found := adverts[session.ID]; !found {
+				adverts[session.ID] = advert
+			}
+		}
+		sessions = append(sessions, session)
+	}
+	for i := range sessions {
+		session := &sessions[i]
+		advert, ok := adverts[session.ID]
+		if !ok {
+			continue
+		}
+		// values reported by the hub are more recent than the advert
+		if session.Version == 0 {
+			session.Version = advert.Version
+			session.Level = advert.Level
+			session.Locked = advert.Locked
+		}
+		if session.Mode == "" {
+			session.Mode = advert.Mode
+		}
+		if session.Phase == "" {
+			session.Phase = advert.Phase
+		}
+	}
+	return sessions
+}
@@ -0,0 +1,80 @@
+package net
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBestSession(t *testing.T) {
+	session := func(id string, size int, ping time.Duration) AvailableSession {
+		return AvailableSession{ID: id, Size: size, Version: ProtocolVersion, Ping: ping}
+	}
+	tests := []struct {
+		name     string
+		sessions []AvailableSession
+		best     string
+	}{
+		{
+			name: "lowest ping",
+			sessions: []AvailableSession{
+				session("far", 3, 180*time.Millisecond),
+				session("near", 1, 40*time.Millisecond),
+			},
+			best: "near",
+		},
+		{
+			name: "players break a ping tie",
+			sessions: []AvailableSession{
+				session("few", 1, 52*time.Millisecond),
+				session("many", 3, 55*time.Millisecond),
+			},
+			best: "many",
+		},
+		{
+			name: "unknown ping last",
+			sessions: []AvailableSession{
+				session("unknown", 3, 0),
+				session("known", 1, 300*time.Millisecond),
+			},
+			best: "known",
+		},
+		{
+			name: "players without pings",
+			sessions: []AvailableSession{
+				session("few", 1, 0),
+				session("many", 2, 0),
+			},
+			best: "many",
+		},
+		{
+			name: "full, locked and incompatible are skipped",
+			sessions: []AvailableSession{
+				session("full", 4, 10*time.Millisecond),
+				{ID: "locked", Size: 1, Version: ProtocolVersion, Locked: true, Ping: 10 * time.Millisecond},
+				{ID: "old", Size: 1, Version: ProtocolVersion - 1, Ping: 10 * time.Millisecond},
+				session("open", 1, 200*time.Millisecond),
+			},
+			best: "open",
+		},
+		{
+			name: "none",
+			sessions: []AvailableSession{
+				session("full", 4, 10*time.Millisecond),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			best := BestSession(test.sessions, 4)
+			if test.best == "" {
+				if best != nil {
+					t.Fatalf("expected no session, got %s", best.ID)
+				}
+				return
+			}
+			if best == nil || best.ID != test.best {
+				t.Fatalf("expected %s, got %v", test.best, best)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package net
+
+// BoatMessage carries the hull and the color a player picked, peers keep the
+// last one of each participant for the whole session.
+type BoatMessage struct {
+	Source string
+	Hull   string
+	Tint   string
+}
+
+func (remoteClient *RemoteClient) SendBoatMessage(hull string, tint string) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnBoat",
+		payload: &BoatMessage{
+			Source: *remoteClient.Client.Id,
+			Hull:   hull,
+			Tint:   tint,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnBoat(message *BoatMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.boatsMutex.Lock()
+			defer remoteClient.boatsMutex.Unlock()
+			remoteClient.boats[msg.Source] = msg
+		})
+	}
+	*reply = "OK"
+	return nil
+}
+
+// Boat returns the boat a participant picked, false until its message
+// arrives.
+func (remoteClient *RemoteClient) Boat(id string) (BoatMessage, bool) {
+	remoteClient.boatsMutex.Lock()
+	defer remoteClient.boatsMutex.Unlock()
+	boat, ok := remoteClient.boats[id]
+	return boat, ok
+}
@@ -0,0 +1,48 @@
+package net
+
+// BumpMessage tells the knockback the host gave to two boats that ran into
+// each other. Aggressor is empty when nobody is penalized.
+type BumpMessage struct {
+	Source       string
+	One          string
+	Other        string
+	OneImpulse   Point
+	OtherImpulse Point
+	Aggressor    string
+	Penalty      bool
+}
+
+type RemoteBumpMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    BumpMessage
+}
+
+func (remoteClient *RemoteClient) SendBumpMessage(bump BumpMessage) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	bump.Source = *remoteClient.Client.Id
+	remoteClient.outbound.push(&outboundMessage{
+		method:  "OnBump",
+		payload: &bump,
+	})
+}
+
+func (remoteClient *RemoteClient) OnBump(message *BumpMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemoteBump.Emit(remoteClient.ctx, RemoteBumpMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
@@ -0,0 +1,78 @@
+package net
+
+const (
+	// share of the waste the group has to collect in a cooperative round
+	CoopWasteShare = 0.9
+)
+
+// CoopProgress is the shared goal of a cooperative round: most of the waste
+// collected and every animal rescued before the timer ends.
+type CoopProgress struct {
+	Waste       int
+	WasteSize   int
+	Animals     int
+	AnimalsSize int
+}
+
+// WasteGoal is the number of waste items the group has to collect.
+func (p CoopProgress) WasteGoal() int {
+	goal := int(float64(p.WasteSize) * CoopWasteShare)
+	if float64(goal) < float64(p.WasteSize)*CoopWasteShare {
+		goal++
+	}
+	return goal
+}
+
+// Cleared tells if the goal was reached, a round without a goal can't be won.
+func (p CoopProgress) Cleared() bool {
+	if p.WasteSize+p.AnimalsSize == 0 {
+		return false
+	}
+	return p.Waste >= p.WasteGoal() && p.Animals >= p.AnimalsSize
+}
+
+// Percent is how close the group is to the goal, from 0 to 100.
+func (p CoopProgress) Percent() int {
+	total := p.WasteGoal() + p.AnimalsSize
+	if total == 0 {
+		return 0
+	}
+	waste := p.Waste
+	if waste > p.WasteGoal() {
+		waste = p.WasteGoal()
+	}
+	animals := p.Animals
+	if animals > p.AnimalsSize {
+		animals = p.AnimalsSize
+	}
+	return (waste + animals) * 100 / total
+}
+
+// CoopMode tells if the players share a goal instead of competing.
+func (gameData *GameData) CoopMode() bool {
+	return gameData.Rules.Mode == SessionModeCoop
+}
+
+// StartCoop sets the goal of a new round for a level with the given animals.
+func (gameData *GameData) StartCoop(animals int) {
+	gameData.Coop = CoopProgress{
+		WasteSize:   len(gameData.WasteLocations),
+		AnimalsSize: animals,
+	}
+}
+
+// UpdateCoop counts the collected waste and the rescued animals of the round,
+// waste spawned by pollution sources raises the goal.
+func (gameData *GameData) UpdateCoop() {
+	gameData.Coop.WasteSize = len(gameData.WasteLocations)
+	gameData.Coop.Waste = 0
+	for _, location := range gameData.WasteLocations {
+		if location.Collected {
+			gameData.Coop.Waste++
+		}
+	}
+	gameData.Coop.Animals = 0
+	for _, participant := range gameData.SessionParticipants {
+		gameData.Coop.Animals += participant.Round.Animals
+	}
+}
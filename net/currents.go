@@ -0,0 +1,48 @@
+package net
+
+// WasteDriftMessage carries the positions of the waste drifting in currents or
+// pulled by magnets, keyed by waste id. Only the host sends it, and only for
+// the waste that moved, so it is delivered reliably.
+type WasteDriftMessage struct {
+	Source    string
+	Positions map[string]Point
+}
+
+type RemoteWasteDriftMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    WasteDriftMessage
+}
+
+func (remoteClient *RemoteClient) SendWasteDriftMessage(positions map[string]Point) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnWasteDrift",
+		payload: &WasteDriftMessage{
+			Source:    *remoteClient.Client.Id,
+			Positions: positions,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnWasteDrift(message *WasteDriftMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		// an update only carries the waste that moved since the previous one,
+		// losing it would leave that waste stale on this peer
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemoteWasteDrift.Emit(remoteClient.ctx, RemoteWasteDriftMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
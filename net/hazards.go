@@ -0,0 +1,46 @@
+package net
+
+// StormMessage tells which storms of the level are blowing, by order in the
+// map. Only the host sends it.
+type StormMessage struct {
+	Source string
+	Active []bool
+}
+
+type RemoteStormMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    StormMessage
+}
+
+func (remoteClient *RemoteClient) SendStormMessage(active []bool) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnStorm",
+		payload: &StormMessage{
+			Source: *remoteClient.Client.Id,
+			Active: active,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnStorm(message *StormMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		// the host repeats the storms every second, it may be dropped
+		remoteClient.receive(msg.Source, true, func() {
+			remoteClient.RemoteStorm.Emit(remoteClient.ctx, RemoteStormMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
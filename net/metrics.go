@@ -0,0 +1,291 @@
+package net
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	PingInterval = 2 * time.Second
+)
+
+type PingMessage struct {
+	From string
+	Sent int64
+}
+
+type PongMessage struct {
+	Time int64
+}
+
+type PeerMetrics struct {
+	ID          string
+	Name        string
+	RTT         time.Duration
+	ClockOffset time.Duration
+	UpdateRate  float64
+	Updates     int
+	BytesIn     int
+	MessagesIn  int
+	Dropped     int
+	LastUpdate  time.Time
+	windowStart time.Time
+	windowCount int
+}
+
+// SinceLastUpdate returns the time elapsed since the last position update
+// received from the peer.
+func (p PeerMetrics) SinceLastUpdate() time.Duration {
+	if p.LastUpdate.IsZero() {
+		return 0
+	}
+	return time.Since(p.LastUpdate)
+}
+
+// Metrics collects traffic statistics of a RemoteClient, it is safe to use
+// from the network goroutines and the game loop at the same time.
+type Metrics struct {
+	mutex       *sync.Mutex
+	Started     time.Time
+	BytesOut    int
+	MessagesOut int
+	Superseded  int
+	Overflowed  int
+	Dropped     int
+	Peers       map[string]*PeerMetrics
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		mutex:   &sync.Mutex{},
+		Started: time.Now(),
+		Peers:   make(map[string]*PeerMetrics),
+	}
+}
+
+func (m *Metrics) peer(id string) *PeerMetrics {
+	peer := m.Peers[id]
+	if peer == nil {
+		peer = &PeerMetrics{ID: id, windowStart: time.Now()}
+		m.Peers[id] = peer
+	}
+	return peer
+}
+
+func (m *Metrics) RecordOut(bytes int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.BytesOut += bytes
+	m.MessagesOut++
+}
+
+func (m *Metrics) RecordIn(from string, bytes int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	peer := m.peer(from)
+	peer.BytesIn += bytes
+	peer.MessagesIn++
+}
+
+func (m *Metrics) RecordUpdate(from string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	now := time.Now()
+	peer := m.peer(from)
+	peer.Updates++
+	peer.LastUpdate = now
+	peer.windowCount++
+	if elapsed := now.Sub(peer.windowStart); elapsed >= time.Second {
+		peer.UpdateRate = float64(peer.windowCount) / elapsed.Seconds()
+		peer.windowCount = 0
+		peer.windowStart = now
+	}
+}
+
+// RecordDrop counts a message that was received but discarded, from may be
+// empty when the sender could not be resolved.
+func (m *Metrics) RecordDrop(from string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Dropped++
+	if from != "" {
+		m.peer(from).Dropped++
+	}
+}
+
+func (m *Metrics) RecordPing(id string, sent time.Time, received time.Time, peerTime int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	peer := m.peer(id)
+	peer.RTT = received.Sub(sent)
+	// the peer answered half way through the round trip
+	midpoint := sent.Add(peer.RTT / 2)
+	peer.ClockOffset = time.UnixMilli(peerTime).Sub(midpoint)
+}
+
+func (m *Metrics) SetSuperseded(superseded int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Superseded = superseded
+}
+
+func (m *Metrics) SetOverflowed(overflowed int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.Overflowed = overflowed
+}
+
+// Snapshot returns a copy of the current metrics with peers sorted by name.
+func (m *Metrics) Snapshot() (Metrics, []PeerMetrics) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	snapshot := Metrics{
+		Started:     m.Started,
+		BytesOut:    m.BytesOut,
+		MessagesOut: m.MessagesOut,
+		Superseded:  m.Superseded,
+		Overflowed:  m.Overflowed,
+		Dropped:     m.Dropped,
+	}
+	peers := make([]PeerMetrics, 0, len(m.Peers))
+	for _, peer := range m.Peers {
+		next := *peer
+		// a peer that stopped sending has no current rate
+		if time.Since(peer.windowStart) > 2*time.Second {
+			next.UpdateRate = 0
+		}
+		peers = append(peers, next)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Name < peers[j].Name
+	})
+	return snapshot, peers
+}
+
+type metricsDump struct {
+	Time        time.Time
+	Uptime      string
+	BytesOut    int
+	MessagesOut int
+	Superseded  int
+	Overflowed  int
+	Dropped     int
+	Peers       []peerDump
+}
+
+type peerDump struct {
+	ID              string
+	Name            string
+	RTT             string
+	ClockOffset     string
+	UpdateRate      float64
+	Updates         int
+	BytesIn         int
+	MessagesIn      int
+	Dropped         int
+	SinceLastUpdate string
+}
+
+func (m *Metrics) Dump(w io.Writer) error {
+	snapshot, peers := m.Snapshot()
+	dump := metricsDump{
+		Time:        time.Now(),
+		Uptime:      time.Since(snapshot.Started).Round(time.Second).String(),
+		BytesOut:    snapshot.BytesOut,
+		MessagesOut: snapshot.MessagesOut,
+		Superseded:  snapshot.Superseded,
+		Overflowed:  snapshot.Overflowed,
+		Dropped:     snapshot.Dropped,
+	}
+	for _, peer := range peers {
+		dump.Peers = append(dump.Peers, peerDump{
+			ID:              peer.ID,
+			Name:            peer.Name,
+			RTT:             peer.RTT.String(),
+			ClockOffset:     peer.ClockOffset.String(),
+			UpdateRate:      peer.UpdateRate,
+			Updates:         peer.Updates,
+			BytesIn:         peer.BytesIn,
+			MessagesIn:      peer.MessagesIn,
+			Dropped:         peer.Dropped,
+			SinceLastUpdate: peer.SinceLastUpdate().Round(time.Millisecond).String(),
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dump)
+}
+
+func (m *Metrics) DumpFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return m.Dump(file)
+}
+
+func payloadSize(payload any) int {
+	if packed, ok := payload.(*PackedMessage); ok {
+		return len(packed.Data)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// Ping answers latency probes from other participants.
+func (remoteClient *RemoteClient) Ping(message *PingMessage, reply *PongMessage) error {
+	*reply = PongMessage{Time: time.Now().UnixMilli()}
+	return nil
+}
+
+func (remoteClient *RemoteClient) probe(stop chan struct{}) {
+	ticker := time.NewTicker(PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			remoteClient.pingParticipants()
+		}
+	}
+}
+
+func (remoteClient *RemoteClient) pingParticipants() {
+	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
+	if rpcClient == nil || remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.Metrics.SetSuperseded(remoteClient.SupersededMessages())
+	remoteClient.Metrics.SetOverflowed(remoteClient.OverflowedMessages())
+	for id, name := range remoteClient.participants() {
+		if id == *remoteClient.Client.Id {
+			continue
+		}
+		sname := remoteClient.Client.GetServiceName(*remoteClient, "Ping", &id)
+		sent := time.Now()
+		var pong PongMessage
+		if err := rpcClient.Call(sname, &PingMessage{From: *remoteClient.Client.Id, Sent: sent.UnixMilli()}, &pong); err != nil || pong.Time == 0 {
+			continue
+		}
+		remoteClient.Metrics.RecordPing(id, sent, time.Now(), pong.Time)
+		remoteClient.Metrics.setName(id, name)
+	}
+}
+
+func (m *Metrics) setName(id string, name *string) {
+	if name == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.peer(id).Name = *name
+}
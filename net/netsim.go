@@ -0,0 +1,283 @@
+package net
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	NetworkSimulatorEnv = "AMARU_NETSIM"
+)
+
+// NetworkConditions describes the network the simulator pretends to be. Loss,
+// duplication and reordering only apply to lossy messages, every other message
+// travels on calls that keep their order and arrive exactly once.
+type NetworkConditions struct {
+	Latency   time.Duration
+	Jitter    time.Duration
+	Loss      float64
+	Duplicate float64
+	Reorder   float64
+}
+
+var (
+	BadNetwork = NetworkConditions{
+		Latency:   200 * time.Millisecond,
+		Jitter:    40 * time.Millisecond,
+		Loss:      0.05,
+		Duplicate: 0.01,
+		Reorder:   0.02,
+	}
+)
+
+func (c NetworkConditions) String() string {
+	return fmt.Sprintf("latency=%s,jitter=%s,loss=%g,dup=%g,reorder=%g", c.Latency, c.Jitter, c.Loss, c.Duplicate, c.Reorder)
+}
+
+// ParseNetworkConditions reads conditions written as
+// "latency=200ms,jitter=40ms,loss=0.05,dup=0.01,reorder=0.02", missing keys
+// stay at zero.
+func ParseNetworkConditions(value string) (NetworkConditions, error) {
+	conditions := NetworkConditions{}
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(field, "=")
+		if !ok {
+			return conditions, fmt.Errorf("netsim: invalid field %q", field)
+		}
+		var err error
+		switch key {
+		case "latency":
+			conditions.Latency, err = time.ParseDuration(raw)
+		case "jitter":
+			conditions.Jitter, err = time.ParseDuration(raw)
+		case "loss":
+			conditions.Loss, err = strconv.ParseFloat(raw, 64)
+		case "dup":
+			conditions.Duplicate, err = strconv.ParseFloat(raw, 64)
+		case "reorder":
+			conditions.Reorder, err = strconv.ParseFloat(raw, 64)
+		default:
+			err = fmt.Errorf("netsim: unknown field %q", key)
+		}
+		if err != nil {
+			return conditions, err
+		}
+	}
+	return conditions, nil
+}
+
+type delivery struct {
+	at      time.Time
+	seq     int
+	deliver func()
+}
+
+type deliveries []*delivery
+
+func (d deliveries) Len() int { return len(d) }
+func (d deliveries) Less(i, j int) bool {
+	if d[i].at.Equal(d[j].at) {
+		return d[i].seq < d[j].seq
+	}
+	return d[i].at.Before(d[j].at)
+}
+func (d deliveries) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+func (d *deliveries) Push(x any)   { *d = append(*d, x.(*delivery)) }
+func (d *deliveries) Pop() any {
+	old := *d
+	last := old[len(old)-1]
+	*d = old[:len(old)-1]
+	return last
+}
+
+// link delays messages travelling in one direction and runs them, in delivery
+// order, on its own goroutine.
+type link struct {
+	mutex   *sync.Mutex
+	pending deliveries
+	last    time.Time
+	seq     int
+	wake    chan struct{}
+	running bool
+}
+
+func newLink() *link {
+	return &link{
+		mutex: &sync.Mutex{},
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+func (l *link) schedule(at time.Time, ordered bool, deliver func()) {
+	l.mutex.Lock()
+	// ordered messages never overtake each other, a reordered one is held
+	// back without delaying the rest
+	if ordered {
+		if at.Before(l.last) {
+			at = l.last
+		}
+		l.last = at
+	}
+	l.seq++
+	heap.Push(&l.pending, &delivery{at: at, seq: l.seq, deliver: deliver})
+	if !l.running {
+		l.running = true
+		go l.run()
+	}
+	l.mutex.Unlock()
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *link) run() {
+	for {
+		l.mutex.Lock()
+		if len(l.pending) == 0 {
+			l.running = false
+			l.mutex.Unlock()
+			return
+		}
+		next := l.pending[0]
+		wait := time.Until(next.at)
+		if wait <= 0 {
+			heap.Pop(&l.pending)
+			l.mutex.Unlock()
+			next.deliver()
+			continue
+		}
+		l.mutex.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-l.wake:
+			timer.Stop()
+		}
+	}
+}
+
+// NetworkSimulator injects latency, jitter, loss, duplication and reordering
+// into the messages a RemoteClient sends and receives.
+type NetworkSimulator struct {
+	mutex      *sync.Mutex
+	enabled    bool
+	conditions NetworkConditions
+	random     *rand.Rand
+	in         *link
+	out        *link
+}
+
+func NewNetworkSimulator() *NetworkSimulator {
+	simulator := &NetworkSimulator{
+		mutex:  &sync.Mutex{},
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		in:     newLink(),
+		out:    newLink(),
+	}
+	if value, ok := os.LookupEnv(NetworkSimulatorEnv); ok {
+		conditions, err := ParseNetworkConditions(value)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			simulator.Enable(conditions)
+		}
+	}
+	return simulator
+}
+
+func (s *NetworkSimulator) Enable(conditions NetworkConditions) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.conditions = conditions
+	s.enabled = true
+}
+
+func (s *NetworkSimulator) Disable() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.enabled = false
+}
+
+// Toggle switches the simulator on with the given conditions, or off when it
+// is already running.
+func (s *NetworkSimulator) Toggle(conditions NetworkConditions) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.enabled = !s.enabled
+	if s.enabled {
+		s.conditions = conditions
+	}
+	return s.enabled
+}
+
+func (s *NetworkSimulator) Conditions() (NetworkConditions, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.conditions, s.enabled
+}
+
+// Inbound hands a received message to deliver once the simulated network
+// lets it through. It returns false when the message was lost.
+func (s *NetworkSimulator) Inbound(lossy bool, deliver func()) bool {
+	return s.transmit(s.in, lossy, deliver)
+}
+
+// Outbound is Inbound for messages on their way out.
+func (s *NetworkSimulator) Outbound(lossy bool, deliver func()) bool {
+	return s.transmit(s.out, lossy, deliver)
+}
+
+func (s *NetworkSimulator) transmit(l *link, lossy bool, deliver func()) bool {
+	s.mutex.Lock()
+	if !s.enabled {
+		s.mutex.Unlock()
+		deliver()
+		return true
+	}
+	conditions := s.conditions
+	lost := lossy && s.random.Float64() < conditions.Loss
+	duplicated := lossy && s.random.Float64() < conditions.Duplicate
+	reordered := lossy && s.random.Float64() < conditions.Reorder
+	delays := []time.Duration{s.delay(conditions)}
+	if duplicated {
+		delays = append(delays, s.delay(conditions))
+	}
+	s.mutex.Unlock()
+
+	if lost {
+		return false
+	}
+	now := time.Now()
+	for _, delay := range delays {
+		if reordered {
+			// hold the message long enough for the next ones to overtake it
+			delay += conditions.Latency/2 + conditions.Jitter
+		}
+		l.schedule(now.Add(delay), !reordered, deliver)
+	}
+	return true
+}
+
+func (s *NetworkSimulator) delay(conditions NetworkConditions) time.Duration {
+	delay := conditions.Latency
+	if conditions.Jitter > 0 {
+		delay += time.Duration(s.random.Int63n(int64(2*conditions.Jitter))) - conditions.Jitter
+	}
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
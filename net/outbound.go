@@ -0,0 +1,219 @@
+package net
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSendRate = 20
+	MaxSendRate     = 60
+	SendRateEnv     = "AMARU_SEND_RATE"
+	// MaxQueuedMessages bounds the messages waiting for the next flush, the
+	// oldest ones are dropped when a slow link cannot keep up.
+	MaxQueuedMessages = 256
+)
+
+type outboundMessage struct {
+	method   string
+	payload  any
+	position bool
+}
+
+// outbound is the single send queue of a RemoteClient. Messages leave in the
+// order they were queued; a position update that has not been sent yet is
+// dropped when the next one is queued, so a position never overtakes the
+// messages queued before it.
+type outbound struct {
+	mutex      *sync.Mutex
+	queue      []*outboundMessage
+	position   *outboundMessage
+	rate       int
+	running    bool
+	stop       chan struct{}
+	superseded int
+	overflowed int
+}
+
+func newOutbound() *outbound {
+	return &outbound{
+		mutex: &sync.Mutex{},
+		queue: []*outboundMessage{},
+		rate:  DefaultSendRate,
+	}
+}
+
+func (o *outbound) push(message *outboundMessage) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if message.position && o.position != nil {
+		o.remove(o.position)
+		o.superseded++
+	}
+	if message.position {
+		o.position = message
+	} else if o.pending() >= MaxQueuedMessages {
+		o.dropOldest()
+	}
+	o.queue = append(o.queue, message)
+}
+
+// pending counts the queued messages that are not the position update.
+func (o *outbound) pending() int {
+	if o.position != nil {
+		return len(o.queue) - 1
+	}
+	return len(o.queue)
+}
+
+func (o *outbound) remove(message *outboundMessage) {
+	for i, queued := range o.queue {
+		if queued == message {
+			o.queue = append(o.queue[:i], o.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *outbound) dropOldest() {
+	for i, message := range o.queue {
+		if message.position {
+			continue
+		}
+		o.queue = append(o.queue[:i], o.queue[i+1:]...)
+		o.overflowed++
+		return
+	}
+}
+
+func (o *outbound) drain() []*outboundMessage {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	pending := o.queue
+	o.queue = []*outboundMessage{}
+	o.position = nil
+	return pending
+}
+
+func (o *outbound) interval() time.Duration {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return time.Second / time.Duration(o.rate)
+}
+
+// ConfiguredSendRate returns the send rate to use, the AMARU_SEND_RATE
+// environment variable wins over the rate stored in the player profile.
+func ConfiguredSendRate(profileRate int) int {
+	if value, ok := os.LookupEnv(SendRateEnv); ok {
+		rate, err := strconv.Atoi(value)
+		if err == nil && rate > 0 {
+			return rate
+		}
+		fmt.Printf("invalid %s value %q\n", SendRateEnv, value)
+	}
+	if profileRate <= 0 {
+		return DefaultSendRate
+	}
+	return profileRate
+}
+
+// SetSendRate changes how many times per second queued messages are flushed.
+func (remoteClient *RemoteClient) SetSendRate(rate int) {
+	if rate <= 0 {
+		return
+	}
+	if rate > MaxSendRate {
+		rate = MaxSendRate
+	}
+	remoteClient.outbound.mutex.Lock()
+	defer remoteClient.outbound.mutex.Unlock()
+	remoteClient.outbound.rate = rate
+}
+
+// SupersededMessages returns how many position updates were replaced by a
+// newer one before they could be sent.
+func (remoteClient *RemoteClient) SupersededMessages() int {
+	remoteClient.outbound.mutex.Lock()
+	defer remoteClient.outbound.mutex.Unlock()
+	return remoteClient.outbound.superseded
+}
+
+// OverflowedMessages returns how many queued messages were dropped because
+// the queue was full.
+func (remoteClient *RemoteClient) OverflowedMessages() int {
+	remoteClient.outbound.mutex.Lock()
+	defer remoteClient.outbound.mutex.Unlock()
+	return remoteClient.outbound.overflowed
+}
+
+func (remoteClient *RemoteClient) startOutbound() {
+	remoteClient.outbound.mutex.Lock()
+	if remoteClient.outbound.running {
+		remoteClient.outbound.mutex.Unlock()
+		return
+	}
+	remoteClient.outbound.running = true
+	remoteClient.outbound.stop = make(chan struct{})
+	stop := remoteClient.outbound.stop
+	remoteClient.outbound.mutex.Unlock()
+
+	go remoteClient.pump(stop)
+	go remoteClient.probe(stop)
+}
+
+func (remoteClient *RemoteClient) stopOutbound() {
+	remoteClient.outbound.mutex.Lock()
+	defer remoteClient.outbound.mutex.Unlock()
+	if !remoteClient.outbound.running {
+		return
+	}
+	remoteClient.outbound.running = false
+	close(remoteClient.outbound.stop)
+}
+
+func (remoteClient *RemoteClient) pump(stop chan struct{}) {
+	interval := remoteClient.outbound.interval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			remoteClient.flush()
+			if next := remoteClient.outbound.interval(); next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+func (remoteClient *RemoteClient) flush() {
+	for _, message := range remoteClient.outbound.drain() {
+		next := message
+		remoteClient.Simulator.Outbound(next.position, func() {
+			if next.position {
+				remoteClient.sendPosition(next.payload.(*Message))
+				return
+			}
+			remoteClient.send(next.method, next.payload)
+		})
+	}
+}
+
+func (remoteClient *RemoteClient) send(method string, payload any) {
+	remoteClient.outmutex.Lock()
+	defer remoteClient.outmutex.Unlock()
+	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
+	if rpcClient == nil {
+		return
+	}
+	sname := remoteClient.Client.GetServiceName(*remoteClient, method, nil)
+	var reply string
+	rpcClient.Call(sname, payload, &reply)
+	remoteClient.Metrics.RecordOut(payloadSize(payload))
+}
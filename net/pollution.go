@@ -0,0 +1,76 @@
+package net
+
+const (
+	// spawn rate multiplier when players are as far behind as they can be
+	MaxPollutionPressure = 3.0
+)
+
+// WasteSpawnMessage carries the waste the host spawned from pollution
+// sources during a round.
+type WasteSpawnMessage struct {
+	Source    string
+	Locations []WasteLocation
+}
+
+type RemoteWasteSpawnMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    WasteSpawnMessage
+}
+
+// PollutionPressure scales the spawn rate of pollution sources. It is 1 while
+// players keep the waste in the water below an even pace for the round and
+// grows up to MaxPollutionPressure as they fall behind.
+func (gameData *GameData) PollutionPressure() float64 {
+	total := len(gameData.WasteLocations)
+	if total == 0 || gameData.Rules.RoundSeconds <= 0 {
+		return 1
+	}
+	pending := 0
+	for _, location := range gameData.WasteLocations {
+		if !location.Collected {
+			pending++
+		}
+	}
+	// at an even pace the water is clean when the timer ends
+	expected := float64(gameData.Counter) / float64(gameData.Rules.RoundSeconds)
+	behind := float64(pending)/float64(total) - expected
+	if behind < 0 {
+		behind = 0
+	}
+	if behind > 1 {
+		behind = 1
+	}
+	return 1 + behind*(MaxPollutionPressure-1)
+}
+
+func (remoteClient *RemoteClient) SendWasteSpawnMessage(locations []WasteLocation) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnWasteSpawn",
+		payload: &WasteSpawnMessage{
+			Source:    *remoteClient.Client.Id,
+			Locations: locations,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnWasteSpawn(message *WasteSpawnMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemoteWasteSpawn.Emit(remoteClient.ctx, RemoteWasteSpawnMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
@@ -0,0 +1,98 @@
+package net
+
+// PowerUpLocation is a power-up the host placed, Position is the center of
+// its shape.
+type PowerUpLocation struct {
+	Id          string
+	Kind        string
+	Position    Point
+	Collected   bool
+	CollectedBy string
+}
+
+type PowerUpSpawnMessage struct {
+	Source   string
+	PowerUps []PowerUpLocation
+}
+
+type RemotePowerUpSpawnMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    PowerUpSpawnMessage
+}
+
+// PowerUpClaimMessage tells the peers which boat picked up a power-up.
+type PowerUpClaimMessage struct {
+	Source string
+	Id     string
+	By     string
+}
+
+type RemotePowerUpClaimMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    PowerUpClaimMessage
+}
+
+func (remoteClient *RemoteClient) SendPowerUpSpawnMessage(powerUps []PowerUpLocation) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnPowerUpSpawn",
+		payload: &PowerUpSpawnMessage{
+			Source:   *remoteClient.Client.Id,
+			PowerUps: powerUps,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnPowerUpSpawn(message *PowerUpSpawnMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemotePowerUpSpawn.Emit(remoteClient.ctx, RemotePowerUpSpawnMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
+
+func (remoteClient *RemoteClient) SendPowerUpClaimMessage(id string, by string) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnPowerUpClaim",
+		payload: &PowerUpClaimMessage{
+			Source: *remoteClient.Client.Id,
+			Id:     id,
+			By:     by,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnPowerUpClaim(message *PowerUpClaimMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemotePowerUpClaim.Emit(remoteClient.ctx, RemotePowerUpClaimMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
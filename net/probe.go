@@ -0,0 +1,120 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nmorenor/chezmoi-net/client"
+)
+
+const (
+	// longest wait for the probe connection and for each session probe
+	probeTimeout = 2 * time.Second
+	// the probe connection is closed after this long without probes
+	probeIdle = 15 * time.Second
+)
+
+// sessionProber keeps a hub connection open while sessions are being listed
+// and times a member query of each listed session through it.
+type sessionProber struct {
+	mutex *sync.Mutex
+	// probes share the session of the connection, one listing probes at a time
+	probing *sync.Mutex
+	client  *client.Client
+	ready   chan struct{}
+	idle    *time.Timer
+}
+
+var prober = &sessionProber{mutex: &sync.Mutex{}, probing: &sync.Mutex{}}
+
+// connection returns the probe connection, it connects on first use and nil
+// is returned when the hub did not answer in time.
+func (p *sessionProber) connection() *client.Client {
+	p.mutex.Lock()
+	if p.client == nil {
+		probe := client.NewClient(NewSocket())
+		ready := make(chan struct{})
+		probe.OnConnect = func() {
+			close(ready)
+		}
+		go func() {
+			<-probe.Interrupt
+			p.drop(probe)
+		}()
+		p.client = probe
+		p.ready = ready
+		go probe.Connect()
+	}
+	if p.idle == nil {
+		p.idle = time.AfterFunc(probeIdle, p.close)
+	} else {
+		p.idle.Reset(probeIdle)
+	}
+	probe, ready := p.client, p.ready
+	p.mutex.Unlock()
+
+	select {
+	case <-ready:
+		return probe
+	case <-time.After(probeTimeout):
+		return nil
+	}
+}
+
+func (p *sessionProber) drop(probe *client.Client) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.client == probe {
+		p.client = nil
+	}
+}
+
+func (p *sessionProber) close() {
+	p.mutex.Lock()
+	probe := p.client
+	p.client = nil
+	p.mutex.Unlock()
+	if probe != nil {
+		probe.Close()
+	}
+}
+
+// ping times a hub round trip for the session, false when it timed out.
+func (p *sessionProber) ping(probe *client.Client, session string) (time.Duration, bool) {
+	probe.Session = &session
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		probe.SessionMembers()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return time.Since(start), true
+	case <-time.After(probeTimeout):
+		return 0, false
+	}
+}
+
+// probeSessions fills the ping of every session, sessions stay at zero when
+// the hub can not be reached.
+func probeSessions(sessions []AvailableSession) {
+	if len(sessions) == 0 {
+		return
+	}
+	prober.probing.Lock()
+	defer prober.probing.Unlock()
+	probe := prober.connection()
+	if probe == nil {
+		return
+	}
+	for i := range sessions {
+		ping, ok := prober.ping(probe, sessions[i].ID)
+		if !ok {
+			// the connection is stuck, the next refresh opens a new one
+			prober.close()
+			return
+		}
+		sessions[i].Ping = ping
+	}
+}
@@ -12,12 +12,28 @@ import (
 )
 
 const (
+	DefaultServer = "nmorenor.com"
+	KCPKey        = "demo"
+)
+
+var (
+	Server                 = DefaultServer
 	ConnectionURL          = "nmorenor.com:1305"
 	WebSocketConnectionURL = "wss://nmorenor.com/ws"
 	AvailableSessionsURL   = "https://nmorenor.com/hub-sessions"
-	KCPKey                 = "demo"
 )
 
+// SetServer points every hub URL to the given host.
+func SetServer(host string) {
+	if host == "" {
+		host = DefaultServer
+	}
+	Server = host
+	ConnectionURL = host + ":1305"
+	WebSocketConnectionURL = "wss://" + host + "/ws"
+	AvailableSessionsURL = "https://" + host + "/hub-sessions"
+}
+
 func NewRemoteClient(currentClient *client.Client, userName string, hostMode bool) *RemoteClient {
 	remoteClient := &RemoteClient{
 		Client:                    currentClient,
@@ -33,11 +49,27 @@ func NewRemoteClient(currentClient *client.Client, userName string, hostMode boo
 		RemoteChat:                signals.New[RemoteChatMessage](),
 		RemoteGameData:            signals.New[RemoteGameDataMessage](),
 		RemoteInitialPositionData: signals.New[RemoteInitialPositionMessage](),
+		RemoteWasteSpawn:          signals.New[RemoteWasteSpawnMessage](),
+		RemoteWasteDrift:          signals.New[RemoteWasteDriftMessage](),
+		RemoteAnimalStates:        signals.New[RemoteAnimalStatesMessage](),
+		RemotePowerUpSpawn:        signals.New[RemotePowerUpSpawnMessage](),
+		RemotePowerUpClaim:        signals.New[RemotePowerUpClaimMessage](),
+		RemoteStorm:               signals.New[RemoteStormMessage](),
+		RemoteBump:                signals.New[RemoteBumpMessage](),
+		RemoteTeamChoice:          signals.New[RemoteTeamChoiceMessage](),
 		SessionEnd:                signals.New[int](),
+		boats:                     map[string]BoatMessage{},
+		boatsMutex:                &sync.Mutex{},
 		ctx:                       context.Background(),
+		outbound:                  newOutbound(),
+		wire:                      newWireSync(),
+		advertiser:                newAdvertiser(),
+		Metrics:                   NewMetrics(),
+		Simulator:                 NewNetworkSimulator(),
 		GameData: &GameData{
 			WasteLocations:      make(map[string]*WasteLocation),
 			SessionParticipants: make(map[string]*SessionParticipant),
+			Rules:               FindRuleSet(RuleSetStandard),
 		},
 	}
 	remoteClient.Client.OnConnect = remoteClient.onReady
@@ -65,6 +97,7 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 
 type WasteLocation struct {
 	Id        string
+	Type      string
 	Location  assets.Path
 	Collected bool
 }
@@ -76,6 +109,11 @@ type SessionParticipant struct {
 	Anim      *string
 	HasPlayer bool
 	Score     int
+	Round     RoundStats
+	Totals    RoundStats
+	Team      int
+	// team asked for in the lobby, TeamNone lets the host pick
+	Choice int
 }
 
 type GameData struct {
@@ -85,6 +123,20 @@ type GameData struct {
 	Counter             int
 	Frames              int
 	OnGameState         bool
+	Round               int
+	MatchOver           bool
+	Rules               RuleSet
+	Coop                CoopProgress
+	// latest animal states of the round, for peers joining mid round
+	Animals []AnimalState
+	// power-ups the host placed during the round, by id
+	PowerUps map[string]*PowerUpLocation
+	// storms blowing in the level, by order in the map
+	Storms []bool
+	// the weather and time of day of the round come from this seed
+	WeatherSeed int64
+	// cooperative rounds of the match where the group reached the goal
+	CoopCleared int
 }
 type Point struct {
 	X float64
@@ -172,6 +224,7 @@ type RemoteClient struct {
 	locationMutex             *sync.Mutex
 	Username                  string
 	Session                   *string
+	Locked                    bool
 	LocalPosition             *Point
 	LocalAnimation            *string
 	GameData                  *GameData
@@ -181,8 +234,23 @@ type RemoteClient struct {
 	RemoteChat                signals.Signal[RemoteChatMessage]
 	RemoteGameData            signals.Signal[RemoteGameDataMessage]
 	RemoteInitialPositionData signals.Signal[RemoteInitialPositionMessage]
+	RemoteWasteSpawn          signals.Signal[RemoteWasteSpawnMessage]
+	RemoteWasteDrift          signals.Signal[RemoteWasteDriftMessage]
+	RemoteAnimalStates        signals.Signal[RemoteAnimalStatesMessage]
+	RemotePowerUpSpawn        signals.Signal[RemotePowerUpSpawnMessage]
+	RemotePowerUpClaim        signals.Signal[RemotePowerUpClaimMessage]
+	RemoteStorm               signals.Signal[RemoteStormMessage]
+	RemoteBump                signals.Signal[RemoteBumpMessage]
+	RemoteTeamChoice          signals.Signal[RemoteTeamChoiceMessage]
 	SessionEnd                signals.Signal[int]
+	boats                     map[string]BoatMessage
+	boatsMutex                *sync.Mutex
 	ctx                       context.Context
+	outbound                  *outbound
+	wire                      *wireSync
+	advertiser                *advertiser
+	Metrics                   *Metrics
+	Simulator                 *NetworkSimulator
 }
 
 // This will be called when web socket is connected
@@ -191,7 +259,9 @@ func (remoteClient *RemoteClient) onReady() {
 	client.RegisterService(remoteClient, remoteClient.Client)
 
 	if remoteClient.Host {
-		remoteClient.Client.StartHosting(remoteClient.Username)
+		advert := remoteClient.advert(SessionPhasePlaying)
+		remoteClient.Client.StartHosting(EncodeSessionAdvert(remoteClient.Username, advert))
+		remoteClient.advertiser.published(advert)
 		//clipboard.Write(clipboard.FmtText, []byte(*remoteClient.Client.Session))
 		fmt.Println("Session: " + *remoteClient.Client.Session)
 	} else {
@@ -203,9 +273,36 @@ func (remoteClient *RemoteClient) onReady() {
 	}
 
 	response := remoteClient.Client.SessionMembers()
-	remoteClient.Participants = response.Members
+	remoteClient.Participants = plainNames(response.Members)
 	remoteClient.HostParticipant = &response.Host
 	remoteClient.Ready = true
+	remoteClient.startOutbound()
+}
+
+func (remoteClient *RemoteClient) advert(phase string) SessionAdvert {
+	return SessionAdvert{
+		Level:   remoteClient.GameData.LevelIndex,
+		Mode:    remoteClient.GameData.Rules.Mode,
+		Phase:   phase,
+		Locked:  remoteClient.Locked,
+		Version: ProtocolVersion,
+	}
+}
+
+// Advertise updates the advert of the hosted session with the given phase and
+// the current level, mode and lock. Peers do nothing.
+func (remoteClient *RemoteClient) Advertise(phase string) {
+	if !remoteClient.Host || remoteClient.Client.Session == nil {
+		return
+	}
+	remoteClient.advertiser.publish(remoteClient.Username, *remoteClient.Client.Session, remoteClient.advert(phase))
+}
+
+// Close stops the outbound queue and disconnects from the hub.
+func (remoteClient *RemoteClient) Close() {
+	remoteClient.stopOutbound()
+	remoteClient.advertiser.close()
+	remoteClient.Client.Close()
 }
 
 func (remoteClient *RemoteClient) Initialize() {
@@ -262,74 +359,76 @@ func (remoteClient *RemoteClient) SetLocalPosition(position *Point, anim *string
 	}
 }
 
+// SendMessage queues a position update. Only the latest queued update is sent
+// when several arrive within the same tick.
 func (remoteClient *RemoteClient) SendMessage(vector Point, position Point, animation string) {
-	remoteClient.outmutex.Lock()
-	defer remoteClient.outmutex.Unlock()
-	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
-
-	msg := &Message{
-		Source:    *remoteClient.Client.Id,
-		Point:     vector,
-		Position:  position,
-		Animation: animation,
+	if remoteClient.Client.Id == nil {
+		return
 	}
+	remoteClient.outbound.push(&outboundMessage{
+		position: true,
+		payload: &Message{
+			Source:    *remoteClient.Client.Id,
+			Point:     vector,
+			Position:  position,
+			Animation: animation,
+		},
+	})
+}
 
-	sname := remoteClient.Client.GetServiceName(*remoteClient, "OnMessage", nil)
-	if rpcClient != nil {
-		var reply string
-		rpcClient.Call(sname, msg, &reply)
+func (remoteClient *RemoteClient) sendPosition(msg *Message) {
+	// prefer the compact encoding, fall back to the plain message when the
+	// sender or the animation can not be indexed or the roster just changed
+	roster := NewRoster(remoteClient.participants())
+	if !remoteClient.wire.usePacked(roster, time.Now()) {
+		remoteClient.send("OnMessage", msg)
+		return
+	}
+	data, ok := EncodePosition(make([]byte, 0, PositionWireSize), roster, msg)
+	if ok {
+		remoteClient.send("OnPackedMessage", &PackedMessage{Data: data})
+		return
 	}
+	remoteClient.send("OnMessage", msg)
 }
 
 func (remoteClient *RemoteClient) SendChatMessage(message string) {
-	remoteClient.outmutex.Lock()
-	defer remoteClient.outmutex.Unlock()
-	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
-
-	msg := &ChatMessage{
-		Source:  *remoteClient.Client.Id,
-		Message: message,
-	}
-
-	sname := remoteClient.Client.GetServiceName(*remoteClient, "OnChatMessage", nil)
-	if rpcClient != nil {
-		var reply string
-		rpcClient.Call(sname, msg, &reply)
+	if remoteClient.Client.Id == nil {
+		return
 	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnChatMessage",
+		payload: &ChatMessage{
+			Source:  *remoteClient.Client.Id,
+			Message: message,
+		},
+	})
 }
 
 func (remoteClient *RemoteClient) SendGameDataMessage(gameData GameData) {
-	remoteClient.outmutex.Lock()
-	defer remoteClient.outmutex.Unlock()
-	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
-
-	msg := &GameDataMessage{
-		Source:   *remoteClient.Client.Id,
-		GameData: gameData,
-	}
-
-	sname := remoteClient.Client.GetServiceName(*remoteClient, "OnGetGameData", nil)
-	if rpcClient != nil {
-		var reply string
-		rpcClient.Call(sname, msg, &reply)
+	if remoteClient.Client.Id == nil {
+		return
 	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnGetGameData",
+		payload: &GameDataMessage{
+			Source:   *remoteClient.Client.Id,
+			GameData: gameData,
+		},
+	})
 }
 
 func (remoteClient *RemoteClient) SendInitialPositionDataMessage(position Point) {
-	remoteClient.outmutex.Lock()
-	defer remoteClient.outmutex.Unlock()
-	rpcClient := remoteClient.Client.GetRpcClientForService(*remoteClient)
-
-	msg := &RemoteInitialPositionMessage{
-		From:     *remoteClient.Client.Id,
-		Position: position,
-	}
-
-	sname := remoteClient.Client.GetServiceName(*remoteClient, "OnNotifyInitialPosition", nil)
-	if rpcClient != nil {
-		var reply string
-		rpcClient.Call(sname, msg, &reply)
+	if remoteClient.Client.Id == nil {
+		return
 	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnNotifyInitialPosition",
+		payload: &RemoteInitialPositionMessage{
+			From:     *remoteClient.Client.Id,
+			Position: position,
+		},
+	})
 }
 
 func (remoteClient *RemoteClient) RequestGameData() {
@@ -367,6 +466,29 @@ func (remoteClient *RemoteClient) RequestGameData() {
 	}
 }
 
+func (remoteClient *RemoteClient) participants() map[string]*string {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	participants := make(map[string]*string, len(remoteClient.Participants))
+	for id, name := range remoteClient.Participants {
+		participants[id] = name
+	}
+	return participants
+}
+
+func (remoteClient *RemoteClient) recordIn(from string, payload any) {
+	remoteClient.Metrics.RecordIn(from, payloadSize(payload))
+	remoteClient.Metrics.setName(from, remoteClient.Participants[from])
+}
+
+// receive passes an incoming message through the network simulator, lossy
+// messages may never be delivered.
+func (remoteClient *RemoteClient) receive(from string, lossy bool, deliver func()) {
+	if !remoteClient.Simulator.Inbound(lossy, deliver) {
+		remoteClient.Metrics.RecordDrop(from)
+	}
+}
+
 func (remoteClient *RemoteClient) FindParticipantFromName(target string) *string {
 	for id, name := range remoteClient.Participants {
 		if *name == target {
@@ -383,12 +505,67 @@ func (remoteClient *RemoteClient) OnMessage(message *Message, reply *string) err
 	remoteClient.inmutex.Lock()
 	defer remoteClient.inmutex.Unlock()
 	if remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, true, func() {
+			remoteClient.Metrics.RecordUpdate(msg.Source)
+			remoteClient.RemoteUpdate.Emit(remoteClient.ctx, RemoteUpdateMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	} else {
+		remoteClient.Metrics.RecordDrop("")
+	}
+	*reply = "OK"
+	return nil
+}
+
+func (remoteClient *RemoteClient) OnPackedMessage(message *PackedMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	*reply = "OK"
+	var msg Message
+	if err := DecodePosition(message.Data, NewRoster(remoteClient.Participants), &msg); err != nil {
+		remoteClient.Metrics.RecordDrop("")
+		// the sender sees another roster, ask for plain messages until the
+		// rosters match again
+		if (err == ErrWireRoster || err == ErrWireParticipant) && remoteClient.wire.requestResync(time.Now()) {
+			remoteClient.sendWireResync()
+		}
+		return nil
+	}
+	remoteClient.recordIn(msg.Source, message)
+	remoteClient.receive(msg.Source, true, func() {
+		remoteClient.Metrics.RecordUpdate(msg.Source)
 		remoteClient.RemoteUpdate.Emit(remoteClient.ctx, RemoteUpdateMessage{
 			Client: remoteClient,
-			From:   &message.Source,
-			Msg:    *message,
+			From:   &msg.Source,
+			Msg:    msg,
 		})
+	})
+	return nil
+}
+
+func (remoteClient *RemoteClient) sendWireResync() {
+	if remoteClient.Client.Id == nil {
+		return
 	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnWireResync",
+		payload: &WireResyncMessage{
+			Source: *remoteClient.Client.Id,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnWireResync(message *WireResyncMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	// the peer asking may not be in our roster yet, which is why it asks
+	remoteClient.recordIn(message.Source, message)
+	remoteClient.wire.pause(time.Now())
 	*reply = "OK"
 	return nil
 }
@@ -397,10 +574,14 @@ func (remoteClient *RemoteClient) OnChatMessage(message *ChatMessage, reply *str
 	remoteClient.inmutex.Lock()
 	defer remoteClient.inmutex.Unlock()
 	if remoteClient.Participants[message.Source] != nil {
-		remoteClient.RemoteChat.Emit(remoteClient.ctx, RemoteChatMessage{
-			Client: remoteClient,
-			From:   &message.Source,
-			Msg:    *message,
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemoteChat.Emit(remoteClient.ctx, RemoteChatMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
 		})
 	}
 	*reply = "OK"
@@ -411,10 +592,13 @@ func (remoteClient *RemoteClient) OnGetGameData(message *GameDataMessage, reply
 	remoteClient.inmutex.Lock()
 	defer remoteClient.inmutex.Unlock()
 	if remoteClient.Participants[message.Source] != nil {
-		remoteClient.RemoteGameData.Emit(remoteClient.ctx, RemoteGameDataMessage{
-			Client: remoteClient,
-			From:   &message.Source,
-			Msg:    &message.GameData,
+		remoteClient.recordIn(message.Source, message)
+		remoteClient.receive(message.Source, false, func() {
+			remoteClient.RemoteGameData.Emit(remoteClient.ctx, RemoteGameDataMessage{
+				Client: remoteClient,
+				From:   &message.Source,
+				Msg:    &message.GameData,
+			})
 		})
 	}
 	*reply = "OK"
@@ -425,7 +609,11 @@ func (remoteClient *RemoteClient) OnNotifyInitialPosition(message *RemoteInitial
 	remoteClient.inmutex.Lock()
 	defer remoteClient.inmutex.Unlock()
 	if remoteClient.Participants[message.From] != nil {
-		remoteClient.RemoteInitialPositionData.Emit(remoteClient.ctx, *message)
+		remoteClient.recordIn(message.From, message)
+		msg := *message
+		remoteClient.receive(msg.From, false, func() {
+			remoteClient.RemoteInitialPositionData.Emit(remoteClient.ctx, msg)
+		})
 	}
 	*reply = "OK"
 	return nil
@@ -452,7 +640,7 @@ func (remoteClient *RemoteClient) onSessionChange(event client.SessionChangeEven
 	defer remoteClient.inmutex.Unlock()
 	response := remoteClient.Client.SessionMembers()
 	oldParticipants := remoteClient.Participants
-	remoteClient.Participants = response.Members
+	remoteClient.Participants = plainNames(response.Members)
 	if event.EventType == client.SESSION_JOIN && remoteClient.Participants[event.EventSource] != nil {
 		remoteClient.SessionJoin.Emit(remoteClient.ctx, SessionJoinMessage{
 			Client:   remoteClient,
@@ -468,6 +656,7 @@ func (remoteClient *RemoteClient) onSessionChange(event client.SessionChangeEven
 		})
 	}
 	if event.EventType == client.SESSION_END {
+		remoteClient.stopOutbound()
 		remoteClient.ResetListeners()
 	}
 }
@@ -480,4 +669,12 @@ func (remoteClient *RemoteClient) ResetListeners() {
 	remoteClient.RemoteChat.Reset()
 	remoteClient.RemoteGameData.Reset()
 	remoteClient.RemoteInitialPositionData.Reset()
+	remoteClient.RemoteWasteSpawn.Reset()
+	remoteClient.RemoteWasteDrift.Reset()
+	remoteClient.RemoteAnimalStates.Reset()
+	remoteClient.RemotePowerUpSpawn.Reset()
+	remoteClient.RemotePowerUpClaim.Reset()
+	remoteClient.RemoteStorm.Reset()
+	remoteClient.RemoteBump.Reset()
+	remoteClient.RemoteTeamChoice.Reset()
 }
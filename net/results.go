@@ -0,0 +1,143 @@
+package net
+
+import (
+	"sort"
+)
+
+// RoundStats are the counters behind a participant score. Round holds the
+// current round, Totals the sum of every finished round of the session.
+type RoundStats struct {
+	Rounds     int
+	Score      int
+	Waste      int
+	Animals    int
+	Collisions int
+}
+
+// Accuracy is the share of contacts that were pickups rather than penalized
+// collisions with other boats.
+func (s RoundStats) Accuracy() float64 {
+	pickups := s.Waste + s.Animals
+	if pickups+s.Collisions == 0 {
+		return 0
+	}
+	return float64(pickups) / float64(pickups+s.Collisions)
+}
+
+func (s *RoundStats) Add(other RoundStats) {
+	s.Rounds += other.Rounds
+	s.Score += other.Score
+	s.Waste += other.Waste
+	s.Animals += other.Animals
+	s.Collisions += other.Collisions
+}
+
+// FinishRound closes the current round of every participant and adds it to
+// the session totals. Only the host calls it, peers get the totals with the
+// game data.
+func (gameData *GameData) FinishRound() {
+	gameData.Animals = nil
+	gameData.PowerUps = nil
+	gameData.Storms = nil
+	for _, participant := range gameData.SessionParticipants {
+		participant.Round.Rounds = 1
+		participant.Round.Score = participant.Score
+		participant.Totals.Add(participant.Round)
+	}
+	if gameData.CoopMode() {
+		gameData.UpdateCoop()
+		if gameData.Coop.Cleared() {
+			gameData.CoopCleared++
+		}
+	}
+}
+
+// StartMatch clears the session totals and goes back to the first round.
+func (gameData *GameData) StartMatch() {
+	gameData.Round = 1
+	gameData.MatchOver = false
+	gameData.CoopCleared = 0
+	for _, participant := range gameData.SessionParticipants {
+		participant.Totals = RoundStats{}
+	}
+}
+
+// LastRound tells if the round being played closes the match, sessions
+// without a round limit never end.
+func (gameData *GameData) LastRound() bool {
+	return gameData.Rules.Rounds > 0 && gameData.Round >= gameData.Rules.Rounds
+}
+
+// StartRound clears the round counters, the animals, the power-ups and the
+// storms of the last level, totals are kept.
+func (gameData *GameData) StartRound() {
+	gameData.Animals = nil
+	gameData.PowerUps = map[string]*PowerUpLocation{}
+	gameData.Storms = nil
+	for _, participant := range gameData.SessionParticipants {
+		participant.Score = 0
+		participant.Round = RoundStats{}
+	}
+}
+
+type Result struct {
+	Rank        int
+	Score       int
+	Participant *SessionParticipant
+}
+
+// RankParticipants orders the participants by the score of the current
+// round. Equal scores share a rank and the next rank is skipped, as in 1, 1, 3.
+func RankParticipants(participants map[string]*SessionParticipant) []Result {
+	return rank(participants, func(participant *SessionParticipant) int {
+		return participant.Score
+	})
+}
+
+// RankStandings orders the participants by their score over the match.
+func RankStandings(participants map[string]*SessionParticipant) []Result {
+	return rank(participants, func(participant *SessionParticipant) int {
+		return participant.Totals.Score
+	})
+}
+
+func rank(participants map[string]*SessionParticipant, score func(participant *SessionParticipant) int) []Result {
+	results := make([]Result, 0, len(participants))
+	for _, participant := range participants {
+		results = append(results, Result{Score: score(participant), Participant: participant})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return ParticipantName(results[i].Participant) < ParticipantName(results[j].Participant)
+	})
+	for i := range results {
+		if i > 0 && results[i].Score == results[i-1].Score {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+	return results
+}
+
+// Winners returns the names of the participants that share the first rank,
+// nobody wins a round without points.
+func Winners(results []Result) []string {
+	winners := []string{}
+	for _, result := range results {
+		if result.Rank != 1 || result.Score <= 0 {
+			break
+		}
+		winners = append(winners, ParticipantName(result.Participant))
+	}
+	return winners
+}
+
+func ParticipantName(participant *SessionParticipant) string {
+	if participant.Name == nil {
+		return participant.Id
+	}
+	return *participant.Name
+}
@@ -0,0 +1,81 @@
+package net
+
+const (
+	RuleSetQuick    = "quick"
+	RuleSetStandard = "standard"
+	RuleSetMarathon = "marathon"
+)
+
+// RuleSet holds the mode, timing and scoring of a session. The host picks it
+// and peers receive it with the game data.
+type RuleSet struct {
+	Name            string
+	Label           string
+	Description     string
+	Mode            string
+	RoundSeconds    int
+	BreakSeconds    int
+	Rounds          int
+	WastePoints     int
+	AnimalPoints    int
+	CollisionPoints int
+	MinWaste        int
+	MaxWaste        int
+}
+
+// RuleSets are the presets offered to hosts, in the order they are listed.
+var RuleSets = []RuleSet{
+	{
+		Name:            RuleSetQuick,
+		Label:           "Quick",
+		Description:     "3 short rounds",
+		Mode:            SessionModeClassic,
+		RoundSeconds:    20,
+		BreakSeconds:    10,
+		Rounds:          3,
+		WastePoints:     1,
+		AnimalPoints:    2,
+		CollisionPoints: 2,
+		MinWaste:        140,
+		MaxWaste:        180,
+	},
+	{
+		Name:            RuleSetStandard,
+		Label:           "Standard",
+		Description:     "3 rounds of 30s",
+		Mode:            SessionModeClassic,
+		RoundSeconds:    30,
+		BreakSeconds:    15,
+		Rounds:          3,
+		WastePoints:     1,
+		AnimalPoints:    2,
+		CollisionPoints: 2,
+		MinWaste:        220,
+		MaxWaste:        260,
+	},
+	{
+		Name:            RuleSetMarathon,
+		Label:           "Marathon",
+		Description:     "5 long rounds",
+		Mode:            SessionModeClassic,
+		RoundSeconds:    60,
+		BreakSeconds:    20,
+		Rounds:          5,
+		WastePoints:     1,
+		AnimalPoints:    3,
+		CollisionPoints: 3,
+		MinWaste:        260,
+		MaxWaste:        300,
+	},
+}
+
+// FindRuleSet returns the preset with the given name, unknown names get the
+// standard rules.
+func FindRuleSet(name string) RuleSet {
+	for _, rules := range RuleSets {
+		if rules.Name == name {
+			return rules
+		}
+	}
+	return FindRuleSet(RuleSetStandard)
+}
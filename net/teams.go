@@ -0,0 +1,162 @@
+package net
+
+import "sort"
+
+const (
+	TeamNone = iota
+	TeamRed
+	TeamBlue
+)
+
+var TeamNames = map[int]string{
+	TeamRed:  "Red",
+	TeamBlue: "Blue",
+}
+
+// TeamMode tells if the session is played in teams.
+func (gameData *GameData) TeamMode() bool {
+	return gameData.Rules.Mode == SessionModeTeams
+}
+
+// TeamChoiceMessage asks the host to put the source in a team, TeamNone
+// lets the host pick.
+type TeamChoiceMessage struct {
+	Source string
+	Team   int
+}
+
+type RemoteTeamChoiceMessage struct {
+	Client *RemoteClient
+	From   *string
+	Msg    TeamChoiceMessage
+}
+
+// BalanceTeams splits the participants in two teams that differ by one player
+// at most. Players keep the team they chose in the lobby, then the one they
+// played for, while it has room. The host calls it before each round so the
+// teams follow the players who joined or left, outside team mode teams are
+// cleared.
+func (gameData *GameData) BalanceTeams() {
+	if !gameData.TeamMode() {
+		for _, participant := range gameData.SessionParticipants {
+			participant.Team = TeamNone
+		}
+		return
+	}
+	ids := make([]string, 0, len(gameData.SessionParticipants))
+	for id := range gameData.SessionParticipants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	room := (len(ids) + 1) / 2
+	sizes := map[int]int{}
+	placed := map[string]int{}
+	keep := func(team func(participant *SessionParticipant) int) {
+		for _, id := range ids {
+			wanted := team(gameData.SessionParticipants[id])
+			if placed[id] != TeamNone || wanted == TeamNone || sizes[wanted] >= room {
+				continue
+			}
+			placed[id] = wanted
+			sizes[wanted]++
+		}
+	}
+	keep(func(participant *SessionParticipant) int { return participant.Choice })
+	keep(func(participant *SessionParticipant) int { return participant.Team })
+	for _, id := range ids {
+		if placed[id] == TeamNone {
+			placed[id] = TeamRed
+			if sizes[TeamBlue] < sizes[TeamRed] {
+				placed[id] = TeamBlue
+			}
+			sizes[placed[id]]++
+		}
+		gameData.SessionParticipants[id].Team = placed[id]
+	}
+}
+
+// AssignTeams puts every participant without a team in the smaller one and
+// leaves the others where they are, for players joining mid round.
+func (gameData *GameData) AssignTeams() {
+	if !gameData.TeamMode() {
+		return
+	}
+	sizes := map[int]int{}
+	for _, participant := range gameData.SessionParticipants {
+		sizes[participant.Team]++
+	}
+	for _, participant := range gameData.SessionParticipants {
+		if participant.Team != TeamNone {
+			continue
+		}
+		participant.Team = TeamRed
+		if sizes[TeamBlue] < sizes[TeamRed] {
+			participant.Team = TeamBlue
+		}
+		sizes[participant.Team]++
+	}
+}
+
+// ChooseTeam records the lobby choice of a participant, the teams of the next
+// round are balanced with it.
+func (gameData *GameData) ChooseTeam(id string, team int) {
+	if participant := gameData.SessionParticipants[id]; participant != nil {
+		participant.Choice = team
+	}
+}
+
+// SendTeamChoice asks the host for a team in the next round.
+func (remoteClient *RemoteClient) SendTeamChoice(team int) {
+	if remoteClient.Client.Id == nil {
+		return
+	}
+	remoteClient.outbound.push(&outboundMessage{
+		method: "OnTeamChoice",
+		payload: &TeamChoiceMessage{
+			Source: *remoteClient.Client.Id,
+			Team:   team,
+		},
+	})
+}
+
+func (remoteClient *RemoteClient) OnTeamChoice(message *TeamChoiceMessage, reply *string) error {
+	remoteClient.inmutex.Lock()
+	defer remoteClient.inmutex.Unlock()
+	if remoteClient.Host && remoteClient.Participants[message.Source] != nil {
+		remoteClient.recordIn(message.Source, message)
+		msg := *message
+		remoteClient.receive(msg.Source, false, func() {
+			remoteClient.RemoteTeamChoice.Emit(remoteClient.ctx, RemoteTeamChoiceMessage{
+				Client: remoteClient,
+				From:   &msg.Source,
+				Msg:    msg,
+			})
+		})
+	}
+	*reply = "OK"
+	return nil
+}
+
+// Teammates tells if two participants play for the same team. In co-op
+// everyone is a teammate, in free for all no one is.
+func (gameData *GameData) Teammates(one string, other string) bool {
+	if gameData.CoopMode() {
+		return true
+	}
+	if !gameData.TeamMode() {
+		return false
+	}
+	a, b := gameData.SessionParticipants[one], gameData.SessionParticipants[other]
+	return a != nil && b != nil && a.Team != TeamNone && a.Team == b.Team
+}
+
+// TeamScores adds up the score of each team.
+func TeamScores(participants map[string]*SessionParticipant, score func(participant *SessionParticipant) int) map[int]int {
+	scores := map[int]int{}
+	for _, participant := range participants {
+		if participant.Team != TeamNone {
+			scores[participant.Team] += score(participant)
+		}
+	}
+	return scores
+}
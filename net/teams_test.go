@@ -0,0 +1,66 @@
+package net
+
+import "testing"
+
+func TestBalanceTeams(t *testing.T) {
+	type player struct {
+		team   int
+		choice int
+	}
+	tests := []struct {
+		name    string
+		players map[string]player
+		teams   map[string]int
+	}{
+		{
+			name:    "newcomers fill the smaller team",
+			players: map[string]player{"a": {team: TeamRed}, "b": {}, "c": {}},
+			teams:   map[string]int{"a": TeamRed, "b": TeamBlue, "c": TeamRed},
+		},
+		{
+			name:    "rebalanced after a player left",
+			players: map[string]player{"a": {team: TeamRed}, "b": {team: TeamRed}, "c": {team: TeamRed}, "d": {team: TeamRed}},
+			teams:   map[string]int{"a": TeamRed, "b": TeamRed, "c": TeamBlue, "d": TeamBlue},
+		},
+		{
+			name:    "choice beats the previous team",
+			players: map[string]player{"a": {team: TeamRed}, "b": {team: TeamBlue, choice: TeamRed}},
+			teams:   map[string]int{"a": TeamBlue, "b": TeamRed},
+		},
+		{
+			name:    "choice only while the team has room",
+			players: map[string]player{"a": {choice: TeamBlue}, "b": {choice: TeamBlue}, "c": {choice: TeamBlue}, "d": {}},
+			teams:   map[string]int{"a": TeamBlue, "b": TeamBlue, "c": TeamRed, "d": TeamRed},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gameData := &GameData{
+				Rules:               RuleSet{Mode: SessionModeTeams},
+				SessionParticipants: map[string]*SessionParticipant{},
+			}
+			for id, player := range test.players {
+				gameData.SessionParticipants[id] = &SessionParticipant{Id: id, Team: player.team, Choice: player.choice}
+			}
+			gameData.BalanceTeams()
+			for id, team := range test.teams {
+				if got := gameData.SessionParticipants[id].Team; got != team {
+					t.Errorf("%s: expected team %d, got %d", id, team, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBalanceTeamsOutsideTeamMode(t *testing.T) {
+	gameData := &GameData{
+		Rules: RuleSet{Mode: SessionModeClassic},
+		SessionParticipants: map[string]*SessionParticipant{
+			"a": {Id: "a", Team: TeamRed, Choice: TeamRed},
+		},
+	}
+	gameData.BalanceTeams()
+	if gameData.SessionParticipants["a"].Team != TeamNone {
+		t.Fatalf("expected no team outside team mode")
+	}
+}
@@ -0,0 +1,215 @@
+package net
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	WireKindPosition byte = 1
+
+	// PositionWireSize is the encoded size of a position update in bytes.
+	PositionWireSize = 15
+
+	// positions are sent in 1/8 pixel steps, velocities in 1/256 steps
+	positionScale = 8.0
+	velocityScale = 256.0
+
+	noAnimation byte = 0xFF
+
+	// peers take a while to agree on the roster after someone joins or
+	// leaves, positions go as plain messages meanwhile
+	RosterSettle = 3 * time.Second
+	// least time between two resync requests of a peer
+	resyncInterval = time.Second
+)
+
+var (
+	ErrWireShort       = errors.New("wire: message too short")
+	ErrWireKind        = errors.New("wire: unknown message kind")
+	ErrWireRoster      = errors.New("wire: roster mismatch")
+	ErrWireParticipant = errors.New("wire: unknown participant")
+
+	wireAnimationsMutex = &sync.RWMutex{}
+	wireAnimations      = []string{}
+	wireAnimationIndex  = map[string]byte{}
+)
+
+// PackedMessage carries a binary encoded update through the RPC transport.
+type PackedMessage struct {
+	Data []byte
+}
+
+// RegisterWireAnimation assigns a stable index to an animation name so it can
+// be sent as a single byte. Every peer must register the same animations in
+// the same order.
+func RegisterWireAnimation(name string) byte {
+	wireAnimationsMutex.Lock()
+	defer wireAnimationsMutex.Unlock()
+	if index, ok := wireAnimationIndex[name]; ok {
+		return index
+	}
+	index := byte(len(wireAnimations))
+	wireAnimations = append(wireAnimations, name)
+	wireAnimationIndex[name] = index
+	return index
+}
+
+func wireAnimation(name string) byte {
+	wireAnimationsMutex.RLock()
+	defer wireAnimationsMutex.RUnlock()
+	if index, ok := wireAnimationIndex[name]; ok {
+		return index
+	}
+	return noAnimation
+}
+
+func wireAnimationName(index byte) string {
+	wireAnimationsMutex.RLock()
+	defer wireAnimationsMutex.RUnlock()
+	if int(index) >= len(wireAnimations) {
+		return ""
+	}
+	return wireAnimations[index]
+}
+
+// Roster maps session participants to short indexes. Every peer builds it from
+// the same member list, so an index resolves to the same participant as long
+// as the checksum matches. The full 32 bit hash is sent, a shorter one lets
+// different rosters match often enough to move the wrong boat.
+type Roster struct {
+	IDs      []string
+	Checksum uint32
+}
+
+func NewRoster(participants map[string]*string) Roster {
+	ids := make([]string, 0, len(participants))
+	for id := range participants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	hash := fnv.New32a()
+	for _, id := range ids {
+		hash.Write([]byte(id))
+	}
+	return Roster{IDs: ids, Checksum: hash.Sum32()}
+}
+
+func (r Roster) Index(id string) (byte, bool) {
+	index := sort.SearchStrings(r.IDs, id)
+	if index >= len(r.IDs) || r.IDs[index] != id || index > math.MaxUint8 {
+		return 0, false
+	}
+	return byte(index), true
+}
+
+// WireResyncMessage asks the peers to send plain position messages for a
+// while, a peer sends it when it gets a packed update it can not decode.
+type WireResyncMessage struct {
+	Source string
+}
+
+// wireSync decides when the packed encoding is safe to send.
+type wireSync struct {
+	mutex      *sync.Mutex
+	checksum   uint32
+	known      bool
+	plainUntil time.Time
+	lastResync time.Time
+}
+
+func newWireSync() *wireSync {
+	return &wireSync{mutex: &sync.Mutex{}}
+}
+
+// usePacked tells if positions can be packed with the roster, a changed
+// roster holds the packed encoding back until every peer has caught up.
+func (w *wireSync) usePacked(roster Roster, now time.Time) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.known || w.checksum != roster.Checksum {
+		w.known = true
+		w.checksum = roster.Checksum
+		w.plainUntil = now.Add(RosterSettle)
+	}
+	return !now.Before(w.plainUntil)
+}
+
+// pause sends plain positions for a while.
+func (w *wireSync) pause(now time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.plainUntil = now.Add(RosterSettle)
+}
+
+// requestResync tells if a resync request can go out now.
+func (w *wireSync) requestResync(now time.Time) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if now.Sub(w.lastResync) < resyncInterval {
+		return false
+	}
+	w.lastResync = now
+	return true
+}
+
+// EncodePosition packs a position update:
+//
+//	kind | roster checksum | participant | animation | x | y | vx | vy
+//
+// The checksum takes four bytes, the rest one byte each up to the animation.
+// Positions are unsigned 13.3 fixed point and velocities signed 8.8 fixed
+// point, values out of range are clamped.
+func EncodePosition(buf []byte, roster Roster, msg *Message) ([]byte, bool) {
+	participant, ok := roster.Index(msg.Source)
+	if !ok {
+		return buf, false
+	}
+	anim := wireAnimation(msg.Animation)
+	if msg.Animation != "" && anim == noAnimation {
+		return buf, false
+	}
+	buf = append(buf, WireKindPosition)
+	buf = binary.LittleEndian.AppendUint32(buf, roster.Checksum)
+	buf = append(buf, participant, anim)
+	buf = binary.LittleEndian.AppendUint16(buf, quantizePosition(msg.Position.X))
+	buf = binary.LittleEndian.AppendUint16(buf, quantizePosition(msg.Position.Y))
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(quantizeVelocity(msg.Point.X)))
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(quantizeVelocity(msg.Point.Y)))
+	return buf, true
+}
+
+func DecodePosition(data []byte, roster Roster, msg *Message) error {
+	if len(data) < PositionWireSize {
+		return ErrWireShort
+	}
+	if data[0] != WireKindPosition {
+		return ErrWireKind
+	}
+	if binary.LittleEndian.Uint32(data[1:]) != roster.Checksum {
+		return ErrWireRoster
+	}
+	if int(data[5]) >= len(roster.IDs) {
+		return ErrWireParticipant
+	}
+	msg.Source = roster.IDs[data[5]]
+	msg.Animation = wireAnimationName(data[6])
+	msg.Position.X = float64(binary.LittleEndian.Uint16(data[7:])) / positionScale
+	msg.Position.Y = float64(binary.LittleEndian.Uint16(data[9:])) / positionScale
+	msg.Point.X = float64(int16(binary.LittleEndian.Uint16(data[11:]))) / velocityScale
+	msg.Point.Y = float64(int16(binary.LittleEndian.Uint16(data[13:]))) / velocityScale
+	return nil
+}
+
+func quantizePosition(value float64) uint16 {
+	return uint16(math.Max(0, math.Min(math.Round(value*positionScale), math.MaxUint16)))
+}
+
+func quantizeVelocity(value float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.Round(value*velocityScale), math.MaxInt16)))
+}
@@ -0,0 +1,218 @@
+package net
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// rpcRequest mirrors the envelope the RPC client writes for every call.
+type rpcRequest struct {
+	Method  string  `json:"method"`
+	Channel string  `json:"channel"`
+	Target  *string `json:"target"`
+	Params  [1]any  `json:"params"`
+	Id      uint64  `json:"id"`
+}
+
+var (
+	benchParticipants = map[string]*string{
+		"5c1f5a52-7a0e-4bd4-9e0c-2b1e1f3b1a01": nil,
+		"0f8d2c8e-3f77-4c55-a1d6-6f4d0b9e7c02": nil,
+		"a3b2c1d0-1111-4222-8333-944455556603": nil,
+	}
+	benchMessage = Message{
+		Source:    "5c1f5a52-7a0e-4bd4-9e0c-2b1e1f3b1a01",
+		Position:  Point{X: 643.25, Y: 312.5},
+		Point:     Point{X: 8, Y: -8},
+		Animation: "right",
+	}
+	benchTarget = "-1"
+)
+
+func init() {
+	for _, name := range []string{"upstop", "downstop", "leftstop", "rightstop", "up", "down", "left", "right"} {
+		RegisterWireAnimation(name)
+	}
+}
+
+// wireSize is the size of the call the hub forwards, base64 encoded.
+func wireSize(b *testing.B, method string, param any) int {
+	data, err := json.Marshal(&rpcRequest{Method: method, Channel: "RemoteClient", Target: &benchTarget, Params: [1]any{param}, Id: 1})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return base64.StdEncoding.EncodedLen(len(data))
+}
+
+func BenchmarkJSONPosition(b *testing.B) {
+	b.ReportAllocs()
+	b.ReportMetric(float64(wireSize(b, "RemoteClient.OnMessage", &benchMessage)), "wire-bytes")
+	for i := 0; i < b.N; i++ {
+		data, err := json.Marshal(&benchMessage)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var decoded Message
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPackedPosition(b *testing.B) {
+	roster := NewRoster(benchParticipants)
+	packed, ok := EncodePosition(nil, roster, &benchMessage)
+	if !ok {
+		b.Fatal("position could not be packed")
+	}
+	b.ReportAllocs()
+	b.ReportMetric(float64(wireSize(b, "RemoteClient.OnPackedMessage", &PackedMessage{Data: packed})), "wire-bytes")
+	buf := make([]byte, 0, PositionWireSize)
+	for i := 0; i < b.N; i++ {
+		data, _ := EncodePosition(buf[:0], roster, &benchMessage)
+		var decoded Message
+		if err := DecodePosition(data, roster, &decoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPositionRoundTrip(t *testing.T) {
+	roster := NewRoster(benchParticipants)
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{"moving", benchMessage},
+		{"stopped", Message{Source: "0f8d2c8e-3f77-4c55-a1d6-6f4d0b9e7c02", Position: Point{X: 32, Y: 960}, Animation: "upstop"}},
+		{"no animation", Message{Source: "a3b2c1d0-1111-4222-8333-944455556603", Position: Point{X: 0.125, Y: 1279.875}, Point: Point{X: -3.5, Y: 0.25}}},
+		{"fractions", Message{Source: "5c1f5a52-7a0e-4bd4-9e0c-2b1e1f3b1a01", Position: Point{X: 100.06, Y: 200.94}, Point: Point{X: 1.001, Y: -1.001}, Animation: "leftstop"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, ok := EncodePosition(nil, roster, &test.msg)
+			if !ok {
+				t.Fatal("position could not be packed")
+			}
+			if len(data) != PositionWireSize {
+				t.Fatalf("encoded %d bytes, want %d", len(data), PositionWireSize)
+			}
+			var decoded Message
+			if err := DecodePosition(data, roster, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Source != test.msg.Source || decoded.Animation != test.msg.Animation {
+				t.Fatalf("decoded %s/%q, want %s/%q", decoded.Source, decoded.Animation, test.msg.Source, test.msg.Animation)
+			}
+			near := func(got, want, step float64) bool {
+				return math.Abs(got-want) <= step/2
+			}
+			if !near(decoded.Position.X, test.msg.Position.X, 1/positionScale) || !near(decoded.Position.Y, test.msg.Position.Y, 1/positionScale) {
+				t.Fatalf("position %v, want %v", decoded.Position, test.msg.Position)
+			}
+			if !near(decoded.Point.X, test.msg.Point.X, 1/velocityScale) || !near(decoded.Point.Y, test.msg.Point.Y, 1/velocityScale) {
+				t.Fatalf("velocity %v, want %v", decoded.Point, test.msg.Point)
+			}
+		})
+	}
+}
+
+func TestDecodePositionRejects(t *testing.T) {
+	roster := NewRoster(benchParticipants)
+	data, ok := EncodePosition(nil, roster, &benchMessage)
+	if !ok {
+		t.Fatal("position could not be packed")
+	}
+	joined := map[string]*string{"ffffffff-0000-4000-8000-000000000004": nil}
+	for id, name := range benchParticipants {
+		joined[id] = name
+	}
+	left := map[string]*string{}
+	for id, name := range benchParticipants {
+		if id != benchMessage.Source {
+			left[id] = name
+		}
+	}
+	wrongKind := append([]byte{}, data...)
+	wrongKind[0] = WireKindPosition + 1
+	tests := []struct {
+		name   string
+		data   []byte
+		roster Roster
+		err    error
+	}{
+		{"someone joined", data, NewRoster(joined), ErrWireRoster},
+		{"someone left", data, NewRoster(left), ErrWireRoster},
+		{"empty roster", data, NewRoster(map[string]*string{}), ErrWireRoster},
+		{"short", data[:PositionWireSize-1], roster, ErrWireShort},
+		{"kind", wrongKind, roster, ErrWireKind},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded Message
+			if err := DecodePosition(test.data, test.roster, &decoded); err != test.err {
+				t.Fatalf("got %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestEncodePositionClamps(t *testing.T) {
+	roster := NewRoster(benchParticipants)
+	maxPosition := math.MaxUint16 / positionScale
+	tests := []struct {
+		name     string
+		position Point
+		velocity Point
+		wantPos  Point
+		wantVel  Point
+	}{
+		{"negative position", Point{X: -10, Y: -0.01}, Point{}, Point{X: 0, Y: 0}, Point{}},
+		{"position past the limit", Point{X: 9000, Y: maxPosition + 1}, Point{}, Point{X: maxPosition, Y: maxPosition}, Point{}},
+		{"position at the limit", Point{X: maxPosition, Y: 0}, Point{}, Point{X: maxPosition, Y: 0}, Point{}},
+		{"velocity past the limits", Point{}, Point{X: 200, Y: -200}, Point{}, Point{X: math.MaxInt16 / velocityScale, Y: math.MinInt16 / velocityScale}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := Message{Source: benchMessage.Source, Position: test.position, Point: test.velocity}
+			data, ok := EncodePosition(nil, roster, &msg)
+			if !ok {
+				t.Fatal("position could not be packed")
+			}
+			var decoded Message
+			if err := DecodePosition(data, roster, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Position != test.wantPos || decoded.Point != test.wantVel {
+				t.Fatalf("got %v %v, want %v %v", decoded.Position, decoded.Point, test.wantPos, test.wantVel)
+			}
+		})
+	}
+}
+
+// a message the packed encoding can not carry goes out as a plain message,
+// EncodePosition reports it and leaves the buffer alone
+func TestEncodePositionFallsBack(t *testing.T) {
+	roster := NewRoster(benchParticipants)
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{"unknown sender", Message{Source: "not-in-the-session", Animation: "up"}},
+		{"unknown animation", Message{Source: benchMessage.Source, Animation: "cartwheel"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := []byte{0xAA}
+			data, ok := EncodePosition(buf, roster, &test.msg)
+			if ok {
+				t.Fatal("position was packed")
+			}
+			if len(data) != len(buf) {
+				t.Fatalf("buffer grew to %d bytes", len(data))
+			}
+		})
+	}
+}
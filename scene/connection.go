@@ -8,12 +8,11 @@ import (
 	"amaru/net"
 	"amaru/system"
 	"context"
-	"runtime"
+	"math/rand"
 	"time"
 
 	"github.com/jakecoffman/cp"
 	"github.com/nmorenor/chezmoi-net/client"
-	cnet "github.com/nmorenor/chezmoi-net/net"
 	"github.com/samber/lo"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -154,7 +153,11 @@ func (menu *ConnectingMenu) createWorld(levelIndex int, session *component.Sessi
 
 	// host creates waste then send location to remote players
 	// animals are set on level state
-	wasteSize := engine.RandomIntRange(component.MinWasteSize, component.MaxWasteSize)
+	if session.Rules.Name == "" {
+		session.Rules = net.FindRuleSet(component.CurrentProfile.RuleSet)
+		session.Rules.Mode = component.CurrentProfile.Mode
+	}
+	wasteSize := engine.RandomIntRange(session.Rules.MinWaste, session.Rules.MaxWaste)
 
 	debugEntity := world.Create(component.Debug)
 	debugComponent := component.Debug.Get(world.Entry(debugEntity))
@@ -167,14 +170,15 @@ func (menu *ConnectingMenu) createWorld(levelIndex int, session *component.Sessi
 		return shape.BB()
 	})
 
-	animalBoxes := lo.Map(selectedLevel.Animals, func(animal assets.Path, idx int) cp.BB {
-		return archetype.CreateBoxFromPath(space, animal, component.AnimalCollisionType).BB()
+	animalBoxes := lo.Map(selectedLevel.Animals, func(animal assets.AnimalSpawn, idx int) cp.BB {
+		return archetype.CreateBoxFromPath(space, animal.Area, component.AnimalCollisionType).BB()
 	})
 	boxes = append(boxes, animalBoxes...)
 	wasteList := archetype.PlaceWasteComponents(world, space, wasteSize, debugComponent, boxes, float64(selectedLevel.Background.Bounds().Dx()), float64(selectedLevel.Background.Bounds().Dy()))
 	wastePaths := lo.Map(wasteList, func(waste *component.WasteData, idx int) *net.WasteLocation {
 		return &net.WasteLocation{
 			Id:        waste.Id,
+			Type:      waste.Type.Name,
 			Location:  waste.Path,
 			Collected: false,
 		}
@@ -193,6 +197,7 @@ func (menu *ConnectingMenu) createWorld(levelIndex int, session *component.Sessi
 			},
 			Speed:        3.0,
 			LeftOffset:   100,
+			Muted:        component.CurrentProfile.Muted,
 			Session:      session,
 			ChatMessages: engine.NewQueue[net.ChatMessage](),
 			WasteSize:    wasteSize,
@@ -207,23 +212,26 @@ func (menu *ConnectingMenu) createWorld(levelIndex int, session *component.Sessi
 }
 
 func (menu *ConnectingMenu) StartSession() {
-	var socket *cnet.ISocket
-	if runtime.GOOS == "js" {
-		socket = engine.Ptr(cnet.NewWebSocket(net.WebSocketConnectionURL))
-	} else {
-		socket = engine.Ptr(cnet.NewKCPSocket(net.ConnectionURL, net.KCPKey))
+	if component.CurrentProfile.Server != net.Server {
+		component.CurrentProfile.Server = net.Server
+		component.CurrentProfile.Save()
 	}
-	menu.remoteClient = net.NewRemoteClient(client.NewClient(*socket), *menu.game.Session.UserName, menu.game.Session.Type == component.SessionTypeHost)
+	menu.remoteClient = net.NewRemoteClient(client.NewClient(net.NewSocket()), *menu.game.Session.UserName, menu.game.Session.Type == component.SessionTypeHost)
+	menu.remoteClient.SetSendRate(net.ConfiguredSendRate(component.CurrentProfile.SendRate))
+	menu.remoteClient.Locked = menu.game.Session.Private
 	if menu.game.Session.SessionID != nil {
 		menu.remoteClient.Session = menu.game.Session.SessionID
 		menu.remoteClient.Client.Session = menu.game.Session.SessionID
 	}
 	menu.game.Session.RemoteClient = menu.remoteClient
-	menu.game.Session.RemoteClient.GameData.Counter = 30
+	menu.game.Session.RemoteClient.GameData.Counter = menu.game.Session.Rules.RoundSeconds
 	menu.game.Session.RemoteClient.GameData.Frames = 0
 	if menu.game.Session.Type == component.SessionTypeHost {
 		menu.game.Session.RemoteClient.GameData.LevelIndex = assets.GameLevelLoader.CurrentLevelIndex
+		menu.game.Session.RemoteClient.GameData.WeatherSeed = rand.Int63()
 		menu.game.Session.RemoteClient.GameData.WasteLocations = menu.wateLocations
+		menu.game.Session.RemoteClient.GameData.Rules = menu.game.Session.Rules
+		menu.game.Session.RemoteClient.GameData.StartMatch()
 	}
 	menu.remoteClient.SessionJoin.AddListener(func(ctx context.Context, sjm net.SessionJoinMessage) {
 		if menu.game.Session.RemoteClient.GameData == nil {
@@ -242,6 +250,9 @@ func (menu *ConnectingMenu) StartSession() {
 			menu.game.Session.RemoteClient.GameData.SessionParticipants = map[string]*net.SessionParticipant{}
 		}
 		menu.game.Session.RemoteClient.GameData.SessionParticipants[participant.Id] = &participant
+		if menu.game.Session.Type == component.SessionTypeHost {
+			menu.game.Session.RemoteClient.GameData.AssignTeams()
+		}
 	})
 	go func(c *client.Client) {
 		c.Connect()
@@ -256,6 +267,9 @@ func (menu *ConnectingMenu) NextScene() archetype.Scene {
 			menu.world = nil
 			menu.systems = nil
 			menu.drawables = nil
+			if menu.game.Session.RemoteClient.GameData.MatchOver {
+				return NewPodiumMenu(menu.game)
+			}
 			return NewWinnerMenu(menu.game)
 		}
 		CleanWorld(menu.world)
@@ -290,7 +304,7 @@ func (menu *ConnectingMenu) Update() {
 			menu.connected = true
 		}
 		if menu.remoteClient.InvalidSession {
-			menu.remoteClient.Client.Close()
+			menu.remoteClient.Close()
 		}
 	}
 
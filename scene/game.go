@@ -61,10 +61,14 @@ func NewGame(screenWidth int, screenHeight int, gameData *component.GameData) *G
 	g.loadLevel()
 
 	// reset all players score
-	for id, sparticipant := range g.gameData.Session.RemoteClient.GameData.SessionParticipants {
-		if sparticipant.Score > 0 {
-			g.gameData.Session.RemoteClient.GameData.SessionParticipants[id].Score = 0
-		}
+	g.gameData.Session.RemoteClient.GameData.StartRound()
+	if g.gameData.Session.Type == component.SessionTypeHost {
+		// teams are balanced again with everyone who joined before the round
+		g.gameData.Session.RemoteClient.GameData.BalanceTeams()
+		g.gameData.Session.RemoteClient.Advertise(net.SessionPhasePlaying)
+	}
+	if g.gameData.Session.RemoteClient.GameData.Round <= 1 || g.gameData.Match.Started.IsZero() {
+		g.gameData.Match = component.MatchData{Started: time.Now()}
 	}
 
 	return g
@@ -76,6 +80,15 @@ func (g *Game) NextScene() archetype.Scene {
 		g.shapes = nil
 		g.systems = nil
 		g.gameData.Session.RemoteClient.ResetListeners()
+		// peers get the totals and the end of the match from the host with the
+		// game data
+		if g.gameData.Session.Type == component.SessionTypeHost {
+			g.gameData.Session.RemoteClient.GameData.FinishRound()
+			g.gameData.Session.RemoteClient.GameData.MatchOver = g.gameData.Session.RemoteClient.GameData.LastRound()
+		}
+		if g.gameData.Session.RemoteClient.GameData.MatchOver {
+			return NewPodiumMenu(g.gameData)
+		}
 		return NewWinnerMenu(g.gameData)
 	}
 
@@ -98,6 +111,7 @@ func (g *Game) loadLevel() {
 	debug := system.NewDebug()
 	remote := system.NewRemoteSystem()
 	hud := system.NewHUD()
+	hazards := system.NewHazards()
 
 	g.systems = []System{
 		system.NewCamera(),
@@ -107,6 +121,12 @@ func (g *Game) loadLevel() {
 		system.NewBounds(),
 		system.NewPlayer(g.space),
 		system.NewControls(),
+		system.NewPollution(g.space, assets.GameLevelLoader.CurrentLevel),
+		system.NewWasteEffects(),
+		system.NewCurrents(assets.GameLevelLoader.CurrentLevel),
+		system.NewAnimals(assets.GameLevelLoader.CurrentLevel),
+		system.NewPowerUps(assets.GameLevelLoader.CurrentLevel),
+		hazards,
 		hud,
 		render,
 		debug,
@@ -114,6 +134,7 @@ func (g *Game) loadLevel() {
 
 	g.drawables = []Drawable{
 		render,
+		hazards,
 		debug,
 		hud,
 	}
@@ -152,6 +173,8 @@ func (g *Game) createWorld() donburi.World {
 		Layer: component.SpriteLayerBackground,
 		Pivot: component.SpritePivotTopLeft,
 	})
+	archetype.NewCurrents(world, levelAsset)
+	archetype.NewWeather(world, g.gameData.Session.RemoteClient.GameData.WeatherSeed)
 
 	overPlayerEntry := world.Entry(
 		world.Create(transform.Transform, component.Sprite),
@@ -194,16 +217,17 @@ func (g *Game) createWorld() donburi.World {
 			HasPlayer: false,
 		}
 	}
-	go func() {
-		g.gameData.Session.RemoteClient.SendInitialPositionDataMessage(net.Point{X: startPos.X, Y: startPos.Y})
-	}()
+	g.gameData.Session.RemoteClient.SendInitialPositionDataMessage(net.Point{X: startPos.X, Y: startPos.Y})
+	g.gameData.Session.RemoteClient.SendBoatMessage(component.CurrentProfile.Hull, component.CurrentProfile.Tint)
 	physics := world.Entry(world.Create(component.Physics))
 	component.Physics.Get(physics).Space = g.space
 
+	g.gameData.Session.RemoteClient.GameData.StartCoop(len(levelAsset.Animals))
 	archetype.PlaceAnimalComponents(world, g.space, debugComponent, levelAsset.Animals, float64(levelAsset.Background.Bounds().Dx()), float64(levelAsset.Background.Bounds().Dy()))
+	archetype.NewHazards(world, g.space, debugComponent, levelAsset)
 	if g.gameData.Session.Type == component.SessionTypeHost {
 		for _, loc := range g.gameData.Session.RemoteClient.GameData.WasteLocations {
-			archetype.PlaceRemoteWasteFromPath(world, g.space, debugComponent, loc.Id, loc.Location, loc.Collected)
+			archetype.PlaceRemoteWasteFromPath(world, g.space, debugComponent, loc.Id, loc.Type, loc.Location, loc.Collected)
 		}
 	}
 
@@ -211,6 +235,7 @@ func (g *Game) createWorld() donburi.World {
 		g.gameData.Session.RemoteClient.GameData.OnGameState = true
 	}
 
+	archetype.NewAntiCheat(world, g.shapes, g.gameData.Session.Type == component.SessionTypeHost)
 	archetype.SetupColliders(world)
 	if !g.gameData.Muted {
 		archetype.StopAudioMenu()
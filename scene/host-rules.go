@@ -0,0 +1,199 @@
+package scene
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/system"
+	"amaru/ui"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/features/transform"
+	"golang.org/x/image/colornames"
+)
+
+type HostRulesMenu struct {
+	world     *donburi.World
+	game      *component.GameData
+	systems   []System
+	drawables []Drawable
+
+	screenWidth  int
+	screenHeight int
+
+	offscreen *ebiten.Image
+	uiHandler *ui.RulesMenuUI
+}
+
+// NewHostRulesMenu lets the host pick the rule preset before the session
+// starts, the choice is kept for the next time.
+func NewHostRulesMenu(screenWidth int, screenHeight int, session *component.SessionData) *HostRulesMenu {
+	menu := &HostRulesMenu{
+		screenWidth:  screenWidth,
+		screenHeight: screenHeight,
+		offscreen:    ebiten.NewImage(screenWidth, screenHeight),
+		uiHandler:    ui.NewRulesMenuUI(component.CurrentProfile.RuleSet, component.CurrentProfile.Mode, component.CurrentProfile.Private),
+	}
+
+	menu.loadMenu(session)
+
+	return menu
+}
+
+func (menu *HostRulesMenu) loadMenu(session *component.SessionData) {
+	selectedLevelIndex := engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
+	assets.GameLevelLoader.LoadLevel(selectedLevelIndex)
+	render := system.NewRenderer()
+	uiRender := system.NewUIRenderer()
+
+	menu.systems = []System{
+		system.NewCamera(),
+		render,
+		uiRender,
+	}
+
+	menu.drawables = []Drawable{
+		render,
+		uiRender,
+	}
+
+	menu.world = engine.Ptr(menu.createWorld(session))
+	menu.game = component.MustFindGame(*menu.world)
+	uiRender.Initialize(*menu.world)
+}
+
+func (menu *HostRulesMenu) UpdateLayout(width, height int) {
+	// do nothing
+}
+
+func (menu *HostRulesMenu) createWorld(session *component.SessionData) donburi.World {
+	rectX := float64(menu.screenWidth/2) - (float64(menu.screenWidth/2) / 2)
+	rectY := float64(menu.screenHeight/2) - (float64(menu.screenHeight/2) / 2)
+
+	menuContainerImage := archetype.DrawMainMenuRoundedRect(menu.offscreen, rectX, rectY, float64(menu.screenWidth/2), float64(menu.screenHeight/2), 5, colornames.White, assets.BlueColor, borderWidth, menuTitle)
+	world := donburi.NewWorld()
+
+	archetype.NewInput(world)
+
+	selectedLevel := assets.GameLevelLoader.CurrentLevel
+
+	level := world.Entry(world.Create(component.Level))
+	component.Level.Get(level).ProgressionTimer = engine.NewTimer(time.Second * 3)
+
+	cameraEntry := archetype.NewCamera(world, menu.screenWidth, menu.screenHeight, math.Vec2{
+		X: 0,
+		Y: 0,
+	})
+
+	component.Camera.Get(cameraEntry).Disabled = true
+
+	levelEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(levelEntry, component.SpriteData{
+		Image: selectedLevel.Background,
+		Layer: component.SpriteLayerBackground,
+		Pivot: component.SpritePivotScreenCenter,
+	})
+	overPlayerEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(overPlayerEntry, component.SpriteData{
+		Image: selectedLevel.OverPlayer,
+		Layer: component.SpriteLayerForeground,
+		Pivot: component.SpritePivotScreenCenter,
+	})
+	menuEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(menuEntry, component.SpriteData{
+		Image: menu.offscreen,
+		Layer: component.SpriteLayerUI,
+		Pivot: component.SpritePivotTopLeft,
+	})
+
+	menuUIEntry := world.Entry(
+		world.Create(transform.Transform, component.UISprite),
+	)
+	component.UISprite.SetValue(menuUIEntry, component.UISpriteData{
+		Image:     menuContainerImage,
+		Layer:     component.SpriteLayerUI,
+		Pivot:     component.SpritePivotScreenCenter,
+		UIHandler: menu.renderUI,
+	})
+
+	if menu.world == nil {
+		game := world.Entry(world.Create(component.Game))
+		component.Game.SetValue(game, component.GameData{
+			Settings: component.Settings{
+				ScreenWidth:  menu.screenWidth,
+				ScreenHeight: menu.screenHeight,
+			},
+			Session:    session,
+			Speed:      3.0,
+			LeftOffset: 0,
+		})
+	}
+
+	if !assets.MenuAdioPlayer.IsPlaying() {
+		assets.MenuAdioPlayer.Rewind()
+		assets.MenuAdioPlayer.Play()
+	}
+
+	archetype.PlayAudioMenu()
+
+	return world
+}
+
+func (menu *HostRulesMenu) renderUI(image *ebiten.Image) *ebiten.Image {
+	menu.uiHandler.Draw(image)
+	return image
+}
+
+func (menu *HostRulesMenu) NextScene() archetype.Scene {
+	if menu.uiHandler.Selected != nil {
+		menu.game.Session.Rules = *menu.uiHandler.Selected
+		menu.game.Session.Private = menu.uiHandler.Private
+		component.CurrentProfile.RuleSet = menu.uiHandler.Selected.Name
+		component.CurrentProfile.Mode = menu.uiHandler.Selected.Mode
+		component.CurrentProfile.Private = menu.uiHandler.Private
+		component.CurrentProfile.Save()
+		CleanWorld(menu.world)
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+		menu.world = nil
+		menu.systems = nil
+		menu.drawables = nil
+		return NewConnectingMenuMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
+	}
+	if menu.uiHandler.Cancel {
+		CleanWorld(menu.world)
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+		menu.world = nil
+		menu.systems = nil
+		menu.drawables = nil
+		return NewStartMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight)
+	}
+	return menu
+}
+
+func (menu *HostRulesMenu) Update() {
+	archetype.PlayAudioMenu()
+	menu.uiHandler.Update()
+	for _, s := range menu.systems {
+		s.Update(*menu.world)
+	}
+
+}
+
+func (menu *HostRulesMenu) Draw(screen *ebiten.Image) {
+	screen.Clear()
+	for _, s := range menu.drawables {
+		s.Draw(*menu.world, screen)
+	}
+}
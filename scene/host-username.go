@@ -27,6 +27,7 @@ type HostUserNameMenu struct {
 
 	offscreen *ebiten.Image
 	uiHandler *ui.TextInputMenu
+	quickPlay bool
 }
 
 func NewHostUserNameMenu(screenWidth int, screenHeight int, session *component.SessionData) *HostUserNameMenu {
@@ -37,11 +38,22 @@ func NewHostUserNameMenu(screenWidth int, screenHeight int, session *component.S
 		uiHandler:    ui.NewTextInputMenu("Your Name:", "Start", "Cancel", "Name"),
 	}
 
+	if name := component.CurrentProfile.UserName(); name != nil {
+		menu.uiHandler.SetValue(*name)
+	}
 	menu.loadMenu(session)
 
 	return menu
 }
 
+// NewQuickPlayUserNameMenu asks for the name once, then looks for a session
+// instead of hosting one.
+func NewQuickPlayUserNameMenu(screenWidth int, screenHeight int, session *component.SessionData) *HostUserNameMenu {
+	menu := NewHostUserNameMenu(screenWidth, screenHeight, session)
+	menu.quickPlay = true
+	return menu
+}
+
 func (menu *HostUserNameMenu) loadMenu(session *component.SessionData) {
 	selectedLevelIndex := engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
 	assets.GameLevelLoader.LoadLevel(selectedLevelIndex)
@@ -155,6 +167,8 @@ func (menu *HostUserNameMenu) renderUI(image *ebiten.Image) *ebiten.Image {
 func (menu *HostUserNameMenu) NextScene() archetype.Scene {
 	if menu.uiHandler.Done {
 		menu.game.Session.UserName = menu.uiHandler.Value
+		component.CurrentProfile.Name = *menu.uiHandler.Value
+		component.CurrentProfile.Save()
 		CleanWorld(menu.world)
 		menu.uiHandler.Ui.Container.RemoveChildren()
 		menu.uiHandler.Ui = nil
@@ -162,7 +176,10 @@ func (menu *HostUserNameMenu) NextScene() archetype.Scene {
 		menu.systems = nil
 		menu.drawables = nil
 
-		return NewConnectingMenuMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
+		if menu.quickPlay {
+			return NewQuickPlayMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
+		}
+		return NewHostRulesMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
 	}
 	if menu.uiHandler.Cancel {
 		CleanWorld(menu.world)
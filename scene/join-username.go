@@ -37,6 +37,9 @@ func NewJoinUserNameMenu(screenWidth int, screenHeight int, session *component.S
 		uiHandler:    ui.NewTextInputMenu("Your Name:", "Continue", "Cancel", "Name"),
 	}
 
+	if name := component.CurrentProfile.UserName(); name != nil {
+		menu.uiHandler.SetValue(*name)
+	}
 	menu.loadMenu(session)
 
 	return menu
@@ -150,6 +153,8 @@ func (menu *JoinUserNameMenu) renderUI(image *ebiten.Image) *ebiten.Image {
 func (menu *JoinUserNameMenu) NextScene() archetype.Scene {
 	if menu.uiHandler.Done {
 		menu.game.Session.UserName = menu.uiHandler.Value
+		component.CurrentProfile.Name = *menu.uiHandler.Value
+		component.CurrentProfile.Save()
 		CleanWorld(menu.world)
 		menu.world = nil
 		menu.systems = nil
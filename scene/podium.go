@@ -0,0 +1,174 @@
+package scene
+
+import (
+	"amaru/archetype"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"amaru/system"
+	"amaru/ui"
+	"context"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+)
+
+// PodiumMenu closes a match with the final standings, the host can start a
+// new match from it.
+type PodiumMenu struct {
+	world     *donburi.World
+	game      *component.GameData
+	systems   []System
+	drawables []Drawable
+
+	screenWidth  int
+	screenHeight int
+
+	offscreen *ebiten.Image
+	uiHandler *ui.PodiumUI
+}
+
+func NewPodiumMenu(gameData *component.GameData) *PodiumMenu {
+	gameData.GameOver = false
+	archetype.RecordMatch(gameData)
+
+	menu := &PodiumMenu{
+		screenWidth:  gameData.Settings.ScreenWidth,
+		screenHeight: gameData.Settings.ScreenHeight,
+		offscreen:    ebiten.NewImage(gameData.Settings.ScreenWidth, gameData.Settings.ScreenHeight),
+		uiHandler:    ui.NewPodiumUI(gameData),
+	}
+
+	archetype.StopAudioMenu()
+
+	menu.loadMenu(gameData)
+
+	return menu
+}
+
+func (menu *PodiumMenu) loadMenu(gameData *component.GameData) {
+	selectNextLevel(gameData)
+	render := system.NewRenderer()
+	uiRender := system.NewUIRenderer()
+
+	menu.systems = []System{
+		system.NewCamera(),
+		render,
+		uiRender,
+	}
+
+	menu.drawables = []Drawable{
+		render,
+		uiRender,
+	}
+
+	menu.world = engine.Ptr(menu.createWorld(gameData))
+	menu.game = gameData
+
+	uiRender.Initialize(*menu.world)
+}
+
+func (menu *PodiumMenu) UpdateLayout(width, height int) {
+	// do nothing
+}
+
+func (menu *PodiumMenu) createWorld(gameData *component.GameData) donburi.World {
+	world, locations := newBreakWorld(menu.offscreen, menu.screenWidth, menu.screenHeight, gameData, menu.renderUI)
+
+	gameData.Session.RemoteClient.RemoteGameData.AddListener(func(ctx context.Context, rcm net.RemoteGameDataMessage) {
+		gameData.Session.RemoteClient.GameData = rcm.Msg
+		if gameData.Session.RemoteClient.GameData.OnGameState {
+			gameData.GameOver = true
+		}
+	})
+
+	gameData.Session.RemoteClient.SessionEnd.AddListener(func(ctx context.Context, val int) {
+		gameData.Session.End = true
+	})
+
+	if gameData.Session.Type == component.SessionTypeHost {
+		gameData.Session.RemoteClient.GameData.Frames = 0
+		gameData.Session.RemoteClient.GameData.OnGameState = false
+		gameData.Session.RemoteClient.GameData.WasteLocations = locations
+		gameData.Session.RemoteClient.SendGameDataMessage(*gameData.Session.RemoteClient.GameData)
+		gameData.Session.RemoteClient.Advertise(net.SessionPhaseBreak)
+	} else {
+		go gameData.Session.RemoteClient.RequestGameData()
+	}
+
+	archetype.StopAudioMenu()
+	archetype.StopAudioGame()
+	archetype.PlayWinnerAudio(gameData)
+
+	return world
+}
+
+func (menu *PodiumMenu) renderUI(image *ebiten.Image) *ebiten.Image {
+	menu.uiHandler.Draw(image)
+	return image
+}
+
+func (menu *PodiumMenu) NextScene() archetype.Scene {
+	if menu.uiHandler.NewMatch && menu.game.Session.Type == component.SessionTypeHost {
+		menu.game.GameOver = true
+	}
+	if menu.game.GameOver {
+		menu.game.Session.RemoteClient.ResetListeners()
+		CleanWorld(menu.world)
+		menu.world = nil
+		menu.systems = nil
+		menu.drawables = nil
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+
+		if menu.game.Session.Type == component.SessionTypeHost && menu.game.Session.RemoteClient.GameData != nil {
+			menu.game.Session.RemoteClient.GameData.StartMatch()
+			menu.game.Session.RemoteClient.GameData.Counter = menu.game.Session.RemoteClient.GameData.Rules.RoundSeconds
+			menu.game.Session.RemoteClient.GameData.Frames = 0
+			menu.game.Session.RemoteClient.GameData.OnGameState = true
+			menu.game.Session.RemoteClient.GameData.BalanceTeams()
+			menu.game.Session.RemoteClient.SendGameDataMessage(*menu.game.Session.RemoteClient.GameData)
+		}
+		menu.game.Session.JustJoined = false
+		return NewGame(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game)
+	}
+	if menu.uiHandler.Lobby && !menu.game.Session.End {
+		menu.game.Session.End = true
+		go menu.game.Session.RemoteClient.Close()
+	}
+	if menu.game.Session.End {
+		CleanWorld(menu.world)
+		menu.game.Session.RemoteClient.ResetListeners()
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+		return NewStartMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight)
+	}
+	return menu
+}
+
+func (menu *PodiumMenu) Update() {
+	if menu.game.Muted {
+		archetype.StopAudioGame()
+		archetype.StopAudioMenu()
+		archetype.StopWinnerAudio()
+	} else {
+		archetype.StopAudioGame()
+		archetype.StopAudioMenu()
+		archetype.PlayWinnerAudio(menu.game)
+	}
+	if menu.game.Session.JustJoined {
+		menu.game.Session.JustJoined = false
+	}
+
+	for _, s := range menu.systems {
+		s.Update(*menu.world)
+	}
+	menu.uiHandler.Update()
+}
+
+func (menu *PodiumMenu) Draw(screen *ebiten.Image) {
+	screen.Clear()
+	for _, s := range menu.drawables {
+		s.Draw(*menu.world, screen)
+	}
+}
@@ -0,0 +1,200 @@
+package scene
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"amaru/system"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/math"
+	"github.com/yohamta/donburi/features/transform"
+	"golang.org/x/image/colornames"
+)
+
+const (
+	findingSessionLabel = "Finding a session..."
+)
+
+type QuickPlayMenu struct {
+	world     *donburi.World
+	game      *component.GameData
+	systems   []System
+	drawables []Drawable
+
+	screenWidth  int
+	screenHeight int
+
+	offscreen *ebiten.Image
+	searching bool
+	searched  bool
+	sessions  *[]net.AvailableSession
+	// the search runs in the background and hands its result to Update
+	found chan *[]net.AvailableSession
+}
+
+func NewQuickPlayMenu(screenWidth int, screenHeight int, session *component.SessionData) *QuickPlayMenu {
+	menu := &QuickPlayMenu{
+		screenWidth:  screenWidth,
+		screenHeight: screenHeight,
+		offscreen:    ebiten.NewImage(screenWidth, screenHeight),
+		found:        make(chan *[]net.AvailableSession, 1),
+	}
+
+	menu.loadMenu(session)
+
+	return menu
+}
+
+func (menu *QuickPlayMenu) loadMenu(session *component.SessionData) {
+	selectedLevelIndex := engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
+	assets.GameLevelLoader.LoadLevel(selectedLevelIndex)
+	render := system.NewRenderer()
+
+	menu.systems = []System{
+		system.NewCamera(),
+		render,
+	}
+
+	menu.drawables = []Drawable{
+		render,
+	}
+
+	menu.world = engine.Ptr(menu.createWorld(session))
+	menu.game = component.MustFindGame(*menu.world)
+}
+
+func (menu *QuickPlayMenu) UpdateLayout(width, height int) {
+	// do nothing
+}
+
+func (menu *QuickPlayMenu) createWorld(session *component.SessionData) donburi.World {
+	rectX := float64(menu.screenWidth/2) - (float64(menu.screenWidth/2) / 2)
+	rectY := float64(menu.screenHeight/2) - (float64(menu.screenHeight/2) / 2)
+
+	menuContainerImage := archetype.DrawMainMenuRoundedRect(menu.offscreen, rectX, rectY, float64(menu.screenWidth/2), float64(menu.screenHeight/2), 5, colornames.White, assets.BlueColor, borderWidth, menuTitle)
+
+	textSize := text.BoundString(assets.MainBigFont, findingSessionLabel)
+	textX := (float64(menuContainerImage.Bounds().Dx()) - float64(textSize.Dx())) / 2
+	textY := float64(menuContainerImage.Bounds().Dy() / 2)
+	text.Draw(menuContainerImage, findingSessionLabel, assets.MainBigFont, int(textX), int(textY), assets.BlueColor)
+
+	world := donburi.NewWorld()
+
+	archetype.NewInput(world)
+
+	level := world.Entry(world.Create(component.Level))
+	component.Level.Get(level).ProgressionTimer = engine.NewTimer(time.Second * 3)
+
+	cameraEntry := archetype.NewCamera(world, menu.screenWidth, menu.screenHeight, math.Vec2{
+		X: 0,
+		Y: 0,
+	})
+	component.Camera.Get(cameraEntry).Disabled = true
+
+	selectedLevel := assets.GameLevelLoader.CurrentLevel
+
+	levelEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(levelEntry, component.SpriteData{
+		Image: selectedLevel.Background,
+		Layer: component.SpriteLayerBackground,
+		Pivot: component.SpritePivotScreenCenter,
+	})
+	overPlayerEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(overPlayerEntry, component.SpriteData{
+		Image: selectedLevel.OverPlayer,
+		Layer: component.SpriteLayerForeground,
+		Pivot: component.SpritePivotScreenCenter,
+	})
+	menuEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(menuEntry, component.SpriteData{
+		Image: menu.offscreen,
+		Layer: component.SpriteLayerUI,
+		Pivot: component.SpritePivotTopLeft,
+	})
+	menuUIEntry := world.Entry(
+		world.Create(transform.Transform, component.Sprite),
+	)
+	component.Sprite.SetValue(menuUIEntry, component.SpriteData{
+		Image: menuContainerImage,
+		Layer: component.SpriteLayerUI,
+		Pivot: component.SpritePivotScreenCenter,
+	})
+
+	game := world.Entry(world.Create(component.Game))
+	component.Game.SetValue(game, component.GameData{
+		Settings: component.Settings{
+			ScreenWidth:  menu.screenWidth,
+			ScreenHeight: menu.screenHeight,
+		},
+		Speed:      3.0,
+		LeftOffset: 0,
+		Session:    session,
+	})
+
+	archetype.PlayAudioMenu()
+
+	return world
+}
+
+func (menu *QuickPlayMenu) NextScene() archetype.Scene {
+	if !menu.searched {
+		return menu
+	}
+	session := menu.game.Session
+	session.UserName = component.CurrentProfile.UserName()
+	var best *net.AvailableSession
+	if menu.sessions != nil {
+		best = net.BestSession(*menu.sessions, component.MaxPlayers)
+	}
+	if best != nil {
+		session.Type = component.SessionTypeJoin
+		session.SessionID = &best.ID
+	} else {
+		// nobody to play with, host a new session
+		session.Type = component.SessionTypeHost
+		session.SessionID = nil
+	}
+	CleanWorld(menu.world)
+	menu.world = nil
+	menu.systems = nil
+	menu.drawables = nil
+	return NewConnectingMenuMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, session)
+}
+
+func (menu *QuickPlayMenu) Update() {
+	archetype.PlayAudioMenu()
+	if !menu.searching {
+		menu.searching = true
+		go func() {
+			menu.found <- net.GetAvailableSessions()
+		}()
+	}
+	select {
+	case sessions := <-menu.found:
+		menu.sessions = sessions
+		menu.searched = true
+	default:
+	}
+	for _, s := range menu.systems {
+		s.Update(*menu.world)
+	}
+}
+
+func (menu *QuickPlayMenu) Draw(screen *ebiten.Image) {
+	screen.Clear()
+	for _, s := range menu.drawables {
+		s.Draw(*menu.world, screen)
+	}
+}
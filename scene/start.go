@@ -166,6 +166,16 @@ func (menu *StartMenu) NextScene() archetype.Scene {
 		return NewAboutMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight)
 	}
 
+	if menu.uiHandler.SelectedOption == ui.Stats {
+		CleanWorld(menu.world)
+		menu.world = nil
+		menu.systems = nil
+		menu.drawables = nil
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+		return NewStatsMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight)
+	}
+
 	if menu.uiHandler.SelectedOption == ui.Host {
 		menu.game.Session = &component.SessionData{
 			Type:          component.SessionTypeHost,
@@ -179,6 +189,21 @@ func (menu *StartMenu) NextScene() archetype.Scene {
 		menu.uiHandler.Ui = nil
 		return NewHostUserNameMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
 	}
+	if menu.uiHandler.SelectedOption == ui.QuickPlay {
+		menu.game.Session = &component.SessionData{
+			PlayerMessage: make(map[string]*component.RemotePlayerMessage),
+		}
+		CleanWorld(menu.world)
+		menu.world = nil
+		menu.systems = nil
+		menu.drawables = nil
+		menu.uiHandler.Ui.Container.RemoveChildren()
+		menu.uiHandler.Ui = nil
+		if component.CurrentProfile.UserName() == nil {
+			return NewQuickPlayUserNameMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
+		}
+		return NewQuickPlayMenu(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game.Session)
+	}
 	if menu.uiHandler.SelectedOption == ui.Join {
 		menu.game.Session = &component.SessionData{
 			Type:          component.SessionTypeJoin,
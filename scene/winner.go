@@ -9,6 +9,7 @@ import (
 	"amaru/system"
 	"amaru/ui"
 	"context"
+	"math/rand"
 	"time"
 
 	"golang.org/x/image/colornames"
@@ -36,24 +37,12 @@ type WinnerMenu struct {
 
 func NewWinnerMenu(gameData *component.GameData) *WinnerMenu {
 	gameData.GameOver = false
-	var winnerParticipant *net.SessionParticipant
-	maxPoints := 0
-	for _, participant := range gameData.Session.RemoteClient.GameData.SessionParticipants {
-		if participant.Score > maxPoints {
-			winnerParticipant = participant
-			maxPoints = participant.Score
-		}
-	}
-	winner := "No winner"
-	if winnerParticipant != nil {
-		winner = *winnerParticipant.Name
-	}
 
 	menu := &WinnerMenu{
 		screenWidth:  gameData.Settings.ScreenWidth,
 		screenHeight: gameData.Settings.ScreenHeight,
 		offscreen:    ebiten.NewImage(gameData.Settings.ScreenWidth, gameData.Settings.ScreenHeight),
-		uiHandler:    ui.NewWinnerUI(winner, gameData),
+		uiHandler:    ui.NewWinnerUI(gameData),
 	}
 
 	archetype.StopAudioMenu()
@@ -64,13 +53,7 @@ func NewWinnerMenu(gameData *component.GameData) *WinnerMenu {
 }
 
 func (menu *WinnerMenu) loadMenu(gameData *component.GameData) {
-	lastIndex := gameData.Session.RemoteClient.GameData.LevelIndex
-	selectedLevelIndex := engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
-	for selectedLevelIndex == lastIndex {
-		selectedLevelIndex = engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
-	}
-	assets.GameLevelLoader.LoadLevel(selectedLevelIndex)
-	gameData.Session.RemoteClient.GameData.LevelIndex = selectedLevelIndex
+	selectNextLevel(gameData)
 	render := system.NewRenderer()
 	uiRender := system.NewUIRenderer()
 
@@ -100,10 +83,74 @@ func (menu *WinnerMenu) UpdateLayout(width, height int) {
 }
 
 func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World {
-	rectX := float64(menu.screenWidth/2) - (float64(menu.screenWidth/2) / 2)
-	rectY := float64(menu.screenHeight/2) - (float64(menu.screenHeight/2) / 2)
+	world, locations := newBreakWorld(menu.offscreen, menu.screenWidth, menu.screenHeight, gameData, menu.renderUI)
+
+	gameData.Session.RemoteClient.RemoteChat.AddListener(func(ctx context.Context, rcm net.RemoteChatMessage) {
+		gameData.ChatMessages.Add(&rcm.Msg)
+	})
+
+	gameData.Session.RemoteClient.RemoteGameData.AddListener(func(ctx context.Context, rcm net.RemoteGameDataMessage) {
+		gameData.Session.RemoteClient.GameData = rcm.Msg
+		if gameData.Session.RemoteClient.GameData.OnGameState {
+			gameData.GameOver = true
+		}
+	})
+
+	gameData.Session.RemoteClient.SessionEnd.AddListener(func(ctx context.Context, val int) {
+		gameData.Session.End = true
+	})
+
+	if gameData.Session.Type == component.SessionTypeHost {
+		// teams of the next round are balanced with the players still here and
+		// their choices once the break ends
+		gameData.Session.RemoteClient.RemoteTeamChoice.AddListener(func(ctx context.Context, rtm net.RemoteTeamChoiceMessage) {
+			gameData.Session.RemoteClient.GameData.ChooseTeam(rtm.Msg.Source, rtm.Msg.Team)
+			gameData.Session.RemoteClient.SendGameDataMessage(*gameData.Session.RemoteClient.GameData)
+		})
+		gameData.Session.RemoteClient.SessionLeave.AddListener(func(ctx context.Context, slm net.SessionLeaveMessage) {
+			delete(gameData.Session.RemoteClient.GameData.SessionParticipants, *slm.Target)
+			gameData.Session.RemoteClient.SendGameDataMessage(*gameData.Session.RemoteClient.GameData)
+		})
+		gameData.Session.RemoteClient.GameData.Counter = gameData.Session.RemoteClient.GameData.Rules.BreakSeconds
+		gameData.Session.RemoteClient.GameData.Frames = 0
+		gameData.Session.RemoteClient.GameData.OnGameState = false
+		gameData.Session.RemoteClient.GameData.WasteLocations = locations
+		gameData.Session.RemoteClient.SendGameDataMessage(*gameData.Session.RemoteClient.GameData)
+		gameData.Session.RemoteClient.Advertise(net.SessionPhaseBreak)
+	} else {
+		go gameData.Session.RemoteClient.RequestGameData()
+	}
+
+	archetype.StopAudioMenu()
+	archetype.StopAudioGame()
+	archetype.PlayWinnerAudio(gameData)
+
+	return world
+}
 
-	menuContainerImage := archetype.DrawMainMenuRoundedRect(menu.offscreen, rectX, rectY, float64(menu.screenWidth/2), float64(menu.screenHeight/2), 5, colornames.White, assets.BlueColor, borderWidth, menuTitle)
+// selectNextLevel picks a level other than the one just played and the
+// weather of the next round, peers get both with the game data.
+func selectNextLevel(gameData *component.GameData) {
+	lastIndex := gameData.Session.RemoteClient.GameData.LevelIndex
+	selectedLevelIndex := engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
+	for selectedLevelIndex == lastIndex {
+		selectedLevelIndex = engine.RandomIntRange(0, assets.GameLevelLoader.LevelsSize)
+	}
+	assets.GameLevelLoader.LoadLevel(selectedLevelIndex)
+	gameData.Session.RemoteClient.GameData.LevelIndex = selectedLevelIndex
+	gameData.Session.RemoteClient.GameData.WeatherSeed = rand.Int63()
+}
+
+// newBreakWorld builds the world shown between rounds with the next level in
+// the background. It returns the waste the host sends for the next round.
+func newBreakWorld(offscreen *ebiten.Image, screenWidth int, screenHeight int, gameData *component.GameData, renderUI func(image *ebiten.Image) *ebiten.Image) (donburi.World, map[string]*net.WasteLocation) {
+	// the results need more room than the other menus
+	menuContainerWidth := float64(screenWidth) * 3 / 4
+	menuContainerHeight := float64(screenHeight) * 4 / 5
+	rectX := (float64(screenWidth) - menuContainerWidth) / 2
+	rectY := (float64(screenHeight) - menuContainerHeight) / 2
+
+	menuContainerImage := archetype.DrawMainMenuRoundedRect(offscreen, rectX, rectY, menuContainerWidth, menuContainerHeight, 5, colornames.White, assets.BlueColor, borderWidth, menuTitle)
 	world := donburi.NewWorld()
 
 	archetype.NewInput(world)
@@ -111,7 +158,7 @@ func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World
 	level := world.Entry(world.Create(component.Level))
 	component.Level.Get(level).ProgressionTimer = engine.NewTimer(time.Second * 3)
 
-	cameraEntry := archetype.NewCamera(world, menu.screenWidth, menu.screenHeight, math.Vec2{
+	cameraEntry := archetype.NewCamera(world, screenWidth, screenHeight, math.Vec2{
 		X: 0,
 		Y: 0,
 	})
@@ -141,7 +188,7 @@ func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World
 		world.Create(transform.Transform, component.Sprite),
 	)
 	component.Sprite.SetValue(menuEntry, component.SpriteData{
-		Image: menu.offscreen,
+		Image: offscreen,
 		Layer: component.SpriteLayerUI,
 		Pivot: component.SpritePivotTopLeft,
 	})
@@ -153,11 +200,11 @@ func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World
 		Image:     menuContainerImage,
 		Layer:     component.SpriteLayerUI,
 		Pivot:     component.SpritePivotScreenCenter,
-		UIHandler: menu.renderUI,
+		UIHandler: renderUI,
 	})
 	// host creates waste then send location to remote players
 	// animals are set on level state
-	wasteSize := engine.RandomIntRange(component.MinWasteSize, component.MaxWasteSize)
+	wasteSize := engine.RandomIntRange(gameData.Session.RemoteClient.GameData.Rules.MinWaste, gameData.Session.RemoteClient.GameData.Rules.MaxWaste)
 
 	debugEntity := world.Create(component.Debug)
 	debugComponent := component.Debug.Get(world.Entry(debugEntity))
@@ -170,14 +217,15 @@ func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World
 		return shape.BB()
 	})
 
-	animalBoxes := lo.Map(selectedLevel.Animals, func(animal assets.Path, idx int) cp.BB {
-		return archetype.CreateBoxFromPath(space, animal, component.AnimalCollisionType).BB()
+	animalBoxes := lo.Map(selectedLevel.Animals, func(animal assets.AnimalSpawn, idx int) cp.BB {
+		return archetype.CreateBoxFromPath(space, animal.Area, component.AnimalCollisionType).BB()
 	})
 	boxes = append(boxes, animalBoxes...)
 	wasteList := archetype.PlaceWasteComponents(world, space, wasteSize, debugComponent, boxes, float64(selectedLevel.Background.Bounds().Dx()), float64(selectedLevel.Background.Bounds().Dy()))
 	wastePaths := lo.Map(wasteList, func(waste *component.WasteData, idx int) *net.WasteLocation {
 		return &net.WasteLocation{
 			Id:        waste.Id,
+			Type:      waste.Type.Name,
 			Location:  waste.Path,
 			Collected: false,
 		}
@@ -187,41 +235,10 @@ func (menu *WinnerMenu) createWorld(gameData *component.GameData) donburi.World
 		locations[location.Id] = location
 	}
 
-	if menu.world == nil {
-		game := world.Entry(world.Create(component.Game))
-		component.Game.SetValue(game, *gameData)
-	}
-
-	gameData.Session.RemoteClient.RemoteChat.AddListener(func(ctx context.Context, rcm net.RemoteChatMessage) {
-		gameData.ChatMessages.Add(&rcm.Msg)
-	})
+	game := world.Entry(world.Create(component.Game))
+	component.Game.SetValue(game, *gameData)
 
-	gameData.Session.RemoteClient.RemoteGameData.AddListener(func(ctx context.Context, rcm net.RemoteGameDataMessage) {
-		gameData.Session.RemoteClient.GameData = rcm.Msg
-		if gameData.Session.RemoteClient.GameData.OnGameState {
-			gameData.GameOver = true
-		}
-	})
-
-	gameData.Session.RemoteClient.SessionEnd.AddListener(func(ctx context.Context, val int) {
-		gameData.Session.End = true
-	})
-
-	if gameData.Session.Type == component.SessionTypeHost {
-		gameData.Session.RemoteClient.GameData.Counter = 15
-		gameData.Session.RemoteClient.GameData.Frames = 0
-		gameData.Session.RemoteClient.GameData.OnGameState = false
-		gameData.Session.RemoteClient.GameData.WasteLocations = locations
-		go gameData.Session.RemoteClient.SendGameDataMessage(*gameData.Session.RemoteClient.GameData)
-	} else {
-		go gameData.Session.RemoteClient.RequestGameData()
-	}
-
-	archetype.StopAudioMenu()
-	archetype.StopAudioGame()
-	archetype.PlayWinnerAudio(gameData)
-
-	return world
+	return world, locations
 }
 
 func (menu *WinnerMenu) renderUI(image *ebiten.Image) *ebiten.Image {
@@ -241,10 +258,12 @@ func (menu *WinnerMenu) NextScene() archetype.Scene {
 		menu.uiHandler.Ui = nil
 
 		if menu.game.Session.Type == component.SessionTypeHost && menu.game.Session.RemoteClient.GameData != nil {
-			menu.game.Session.RemoteClient.GameData.Counter = 30
+			menu.game.Session.RemoteClient.GameData.Counter = menu.game.Session.RemoteClient.GameData.Rules.RoundSeconds
 			menu.game.Session.RemoteClient.GameData.Frames = 0
 			menu.game.Session.RemoteClient.GameData.OnGameState = true
-			go menu.game.Session.RemoteClient.SendGameDataMessage(*menu.game.Session.RemoteClient.GameData)
+			menu.game.Session.RemoteClient.GameData.Round++
+			menu.game.Session.RemoteClient.GameData.BalanceTeams()
+			menu.game.Session.RemoteClient.SendGameDataMessage(*menu.game.Session.RemoteClient.GameData)
 		}
 		menu.game.Session.JustJoined = false
 		return NewGame(menu.game.Settings.ScreenWidth, menu.game.Settings.ScreenHeight, menu.game)
@@ -0,0 +1,258 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"context"
+	"math/rand"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// frames between two animal updates sent by the host
+	animalSendFrames = 6
+	// share of the distance to the host position a peer moves each frame
+	animalSmoothing = 0.2
+	wanderAttempts  = 5
+)
+
+// Animals runs the animal AI on the host: animals wander around their spot,
+// flee from fast boats and get entangled in waste. Peers follow the states
+// the host sends.
+type Animals struct {
+	game          *component.GameData
+	space         *cp.Space
+	islands       []*cp.Shape
+	animalQuery   *query.Query
+	playerQuery   *query.Query
+	wasteQuery    *query.Query
+	stateMessages *engine.Queue[net.AnimalStatesMessage]
+	targets       map[int]cp.Vector
+	mapWidth      float64
+	mapHeight     float64
+	frames        int
+	initialized   bool
+}
+
+func NewAnimals(level *assets.Level) *Animals {
+	return &Animals{
+		animalQuery:   query.NewQuery(filter.Contains(component.Animal)),
+		playerQuery:   query.NewQuery(filter.Contains(component.Player)),
+		wasteQuery:    query.NewQuery(filter.Contains(component.Waste)),
+		stateMessages: engine.NewQueue[net.AnimalStatesMessage](),
+		targets:       map[int]cp.Vector{},
+		mapWidth:      float64(level.Background.Bounds().Dx()),
+		mapHeight:     float64(level.Background.Bounds().Dy()),
+	}
+}
+
+func (a *Animals) Update(w donburi.World) {
+	if a.game == nil {
+		a.game = component.MustFindGame(w)
+		if a.game == nil {
+			return
+		}
+	}
+	if a.space == nil {
+		physics, _ := archetype.MustFindPhysics(w)
+		if physics == nil {
+			return
+		}
+		a.space = physics.Space
+	}
+	if !a.initialized {
+		a.initialized = true
+		if anticheat := component.FindAntiCheat(w); anticheat != nil {
+			a.islands = anticheat.Islands
+		}
+		a.game.Session.RemoteClient.RemoteAnimalStates.AddListener(func(ctx context.Context, rasm net.RemoteAnimalStatesMessage) {
+			a.stateMessages.Add(&rasm.Msg)
+		})
+		// peers joining mid round start from the last states of the host,
+		// the rescues are already in the scores
+		if a.game.Session.Type != component.SessionTypeHost {
+			a.applyStates(w, a.game.Session.RemoteClient.GameData.Animals, false)
+		}
+	}
+
+	if a.game.Session.Type == component.SessionTypeHost {
+		a.updateHost(w)
+	} else {
+		a.updatePeer(w)
+	}
+}
+
+func (a *Animals) updateHost(w donburi.World) {
+	a.animalQuery.Each(w, func(entry *donburi.Entry) {
+		animal := component.Animal.Get(entry)
+		if animal.Collected {
+			return
+		}
+		if animal.Entangled {
+			component.Sprite.Get(entry).ColorOverride = component.EntangledTint
+			return
+		}
+		center := animal.Shape.Body().Position()
+		a.scare(w, animal, center)
+
+		var velocity cp.Vector
+		if animal.FleeFrames > 0 {
+			animal.FleeFrames--
+			velocity = center.Sub(animal.FleeFrom).Normalize().Mult(animal.Species.FleeSpeed)
+		} else {
+			if center.Distance(animal.Target) < 4 {
+				animal.Target = a.wanderTarget(animal)
+			}
+			velocity = animal.Target.Sub(center).Normalize().Mult(animal.Species.SwimSpeed)
+		}
+		next := center.Add(velocity.Mult(1.0 / 60))
+		if velocity.LengthSq() == 0 || !a.free(animal, next) {
+			// blocked, try somewhere else
+			animal.FleeFrames = 0
+			animal.Target = a.wanderTarget(animal)
+			return
+		}
+		archetype.MoveAnimal(animal, next)
+		a.entangle(w, animal)
+	})
+
+	a.frames++
+	if a.frames%animalSendFrames == 0 {
+		states := a.states(w)
+		a.game.Session.RemoteClient.GameData.Animals = states
+		a.game.Session.RemoteClient.SendAnimalStatesMessage(states)
+	}
+}
+
+// scare makes the animal flee from the closest fast boat in range.
+func (a *Animals) scare(w donburi.World, animal *component.AnimalData, center cp.Vector) {
+	closest := animal.Species.FleeRadius
+	a.playerQuery.Each(w, func(entry *donburi.Entry) {
+		player := component.Player.Get(entry)
+		if player == nil || player.Body == nil {
+			return
+		}
+		position := player.Body.Position()
+		distance := position.Distance(center)
+		if distance < closest && player.Body.Velocity().Length() > component.FleeBoatSpeed {
+			closest = distance
+			animal.FleeFrom = position
+			animal.FleeFrames = component.FleeFrames
+		}
+	})
+}
+
+// entangle may catch the animal in the waste it touches.
+func (a *Animals) entangle(w donburi.World, animal *component.AnimalData) {
+	bb := animal.Shape.BB()
+	a.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		if animal.Entangled || waste.Collected || waste.Shape == nil {
+			return
+		}
+		if engine.BBIntersects(bb, waste.Shape.BB()) && rand.Float64() < animal.Species.EntangleChance/60 {
+			animal.Entangled = true
+			animal.FleeFrames = 0
+		}
+	})
+}
+
+func (a *Animals) wanderTarget(animal *component.AnimalData) cp.Vector {
+	for attempt := 0; attempt < wanderAttempts; attempt++ {
+		target := animal.Home.Add(cp.Vector{
+			X: engine.RandomRange(-component.WanderRadius, component.WanderRadius),
+			Y: engine.RandomRange(-component.WanderRadius, component.WanderRadius),
+		})
+		if a.free(animal, target) {
+			return target
+		}
+	}
+	return animal.Home
+}
+
+// free tells if the animal can be at the position: inside the map, close to
+// its home and away from the islands.
+func (a *Animals) free(animal *component.AnimalData, position cp.Vector) bool {
+	if position.X < 16 || position.Y < 16 || position.X > a.mapWidth-16 || position.Y > a.mapHeight-16 {
+		return false
+	}
+	if position.Distance(animal.Home) > component.LeashRadius {
+		return false
+	}
+	return !archetype.NearIsland(a.islands, position, 16)
+}
+
+func (a *Animals) states(w donburi.World) []net.AnimalState {
+	states := []net.AnimalState{}
+	a.animalQuery.Each(w, func(entry *donburi.Entry) {
+		animal := component.Animal.Get(entry)
+		position := animal.Shape.Body().Position()
+		states = append(states, net.AnimalState{
+			Id:        animal.Id,
+			Position:  net.Point{X: position.X, Y: position.Y},
+			Entangled: animal.Entangled,
+			Collected: animal.Collected,
+			RescuedBy: animal.RescuedBy,
+		})
+	})
+	return states
+}
+
+func (a *Animals) updatePeer(w donburi.World) {
+	for a.stateMessages.Length() > 0 {
+		message := a.stateMessages.Remove()
+		a.applyStates(w, message.Animals, true)
+	}
+	a.animalQuery.Each(w, func(entry *donburi.Entry) {
+		animal := component.Animal.Get(entry)
+		target, ok := a.targets[animal.Id]
+		if !ok || animal.Collected {
+			return
+		}
+		center := animal.Shape.Body().Position()
+		if center.Distance(target) < 0.5 {
+			delete(a.targets, animal.Id)
+			return
+		}
+		archetype.MoveAnimal(animal, center.Lerp(target, animalSmoothing))
+	})
+}
+
+// applyStates copies the host states to the local animals, rescues give the
+// points to the rescuer when score is set.
+func (a *Animals) applyStates(w donburi.World, states []net.AnimalState, score bool) {
+	byId := make(map[int]net.AnimalState, len(states))
+	for _, state := range states {
+		byId[state.Id] = state
+	}
+	a.animalQuery.Each(w, func(entry *donburi.Entry) {
+		animal := component.Animal.Get(entry)
+		state, ok := byId[animal.Id]
+		if !ok || animal.Collected {
+			return
+		}
+		if state.Collected {
+			if score && state.RescuedBy != "" {
+				archetype.RescueAnimal(a.game, animal, state.RescuedBy)
+			} else {
+				animal.Collected = true
+				component.Sprite.Get(entry).Hidden = true
+			}
+			return
+		}
+		animal.Entangled = state.Entangled
+		if animal.Entangled {
+			component.Sprite.Get(entry).ColorOverride = component.EntangledTint
+		} else {
+			component.Sprite.Get(entry).ColorOverride = nil
+		}
+		a.targets[animal.Id] = cp.Vector{X: state.Position.X, Y: state.Position.Y}
+	})
+}
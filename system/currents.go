@@ -0,0 +1,198 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"context"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	currentMarkSpacing = 48.0
+	currentMarkLength  = 14.0
+	// frames between two updates of the drifting waste sent by the host
+	driftSendFrames = 15
+	// share of the distance to the host position a peer moves each frame
+	driftSmoothing = 0.2
+)
+
+var (
+	currentFillColor = color.RGBA{R: 255, G: 255, B: 255, A: 16}
+	currentMarkColor = color.RGBA{R: 255, G: 255, B: 255, A: 80}
+)
+
+// Currents animates the current overlays and drifts the waste inside the
+// current zones. The host moves the waste and sends its positions, peers
+// follow them.
+type Currents struct {
+	game          *component.GameData
+	space         *cp.Space
+	currents      *component.CurrentsData
+	islands       []*cp.Shape
+	wasteQuery    *query.Query
+	overlayQuery  *query.Query
+	driftMessages *engine.Queue[net.WasteDriftMessage]
+	targets       map[string]cp.Vector
+	moved         map[string]net.Point
+	mapWidth      float64
+	mapHeight     float64
+	frames        int
+	listening     bool
+}
+
+func NewCurrents(level *assets.Level) *Currents {
+	return &Currents{
+		wasteQuery:    query.NewQuery(filter.Contains(component.Waste)),
+		overlayQuery:  query.NewQuery(filter.Contains(component.Sprite, component.CurrentOverlay)),
+		driftMessages: engine.NewQueue[net.WasteDriftMessage](),
+		targets:       map[string]cp.Vector{},
+		moved:         map[string]net.Point{},
+		mapWidth:      float64(level.Background.Bounds().Dx()),
+		mapHeight:     float64(level.Background.Bounds().Dy()),
+	}
+}
+
+func (c *Currents) Update(w donburi.World) {
+	if c.game == nil {
+		c.game = component.MustFindGame(w)
+		if c.game == nil {
+			return
+		}
+	}
+	if c.space == nil {
+		physics, _ := archetype.MustFindPhysics(w)
+		if physics == nil {
+			return
+		}
+		c.space = physics.Space
+	}
+	if c.currents == nil {
+		c.currents = component.FindCurrents(w)
+		if c.currents == nil {
+			return
+		}
+	}
+	if !c.listening {
+		if anticheat := component.FindAntiCheat(w); anticheat != nil {
+			c.islands = anticheat.Islands
+		}
+		c.listening = true
+		c.game.Session.RemoteClient.RemoteWasteDrift.AddListener(func(ctx context.Context, rwdm net.RemoteWasteDriftMessage) {
+			c.driftMessages.Add(&rwdm.Msg)
+		})
+	}
+
+	c.animateOverlays(w)
+	if c.game.Session.Type == component.SessionTypeHost {
+		c.driftWaste(w)
+	} else {
+		c.followWaste(w)
+	}
+}
+
+func (c *Currents) animateOverlays(w donburi.World) {
+	c.overlayQuery.Each(w, func(entry *donburi.Entry) {
+		overlay := component.CurrentOverlay.Get(entry)
+		image := component.Sprite.Get(entry).Image
+		overlay.Phase = math.Mod(overlay.Phase+overlay.Zone.Strength/60, currentMarkSpacing)
+
+		image.Clear()
+		image.Fill(currentFillColor)
+		width, height := float64(image.Bounds().Dx()), float64(image.Bounds().Dy())
+		direction := cp.Vector{X: overlay.Zone.Direction.X, Y: overlay.Zone.Direction.Y}
+		normal := direction.Perp()
+		center := cp.Vector{X: width / 2, Y: height / 2}
+		// marks are laid on a grid aligned with the current so moving them by
+		// one spacing gives the same picture
+		reach := math.Hypot(width, height)/2 + currentMarkSpacing
+		row := 0
+		for v := -reach; v < reach; v += currentMarkSpacing {
+			stagger := float64(row%2) * currentMarkSpacing / 2
+			for u := -reach; u < reach; u += currentMarkSpacing {
+				from := center.Add(direction.Mult(u + overlay.Phase + stagger)).Add(normal.Mult(v))
+				to := from.Add(direction.Mult(currentMarkLength))
+				vector.StrokeLine(image, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), 2, currentMarkColor, true)
+			}
+			row++
+		}
+
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendDestinationIn
+		image.DrawImage(overlay.Mask, op)
+	})
+}
+
+func (c *Currents) driftWaste(w donburi.World) {
+	gameData := c.game.Session.RemoteClient.GameData
+	c.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		if waste.Collected || waste.Shape == nil {
+			return
+		}
+		center := waste.Shape.Body().Position()
+		drift := c.currents.DriftAt(center).Mult(component.WasteDriftFactor / 60)
+		if drift.X == 0 && drift.Y == 0 {
+			return
+		}
+		next := center.Add(drift)
+		halfWidth, halfHeight := float64(waste.Type.Width)/2, float64(waste.Type.Height)/2
+		if next.X < halfWidth || next.Y < halfHeight || next.X > c.mapWidth-halfWidth || next.Y > c.mapHeight-halfHeight {
+			return
+		}
+		// waste stops at the islands
+		if archetype.NearIsland(c.islands, next, math.Max(halfWidth, halfHeight)) {
+			return
+		}
+		archetype.MoveWaste(waste, next)
+		if location := gameData.WasteLocations[waste.Id]; location != nil {
+			location.Location = waste.Path
+		}
+		c.moved[waste.Id] = net.Point{X: next.X, Y: next.Y}
+	})
+
+	c.frames++
+	if c.frames%driftSendFrames == 0 && len(c.moved) > 0 {
+		c.game.Session.RemoteClient.SendWasteDriftMessage(c.moved)
+		c.moved = map[string]net.Point{}
+	}
+}
+
+func (c *Currents) followWaste(w donburi.World) {
+	for c.driftMessages.Length() > 0 {
+		message := c.driftMessages.Remove()
+		for id, position := range message.Positions {
+			c.targets[id] = cp.Vector{X: position.X, Y: position.Y}
+		}
+	}
+	if len(c.targets) == 0 {
+		return
+	}
+	gameData := c.game.Session.RemoteClient.GameData
+	c.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		target, ok := c.targets[waste.Id]
+		if !ok || waste.Shape == nil {
+			return
+		}
+		center := waste.Shape.Body().Position()
+		if center.Distance(target) < 0.5 {
+			delete(c.targets, waste.Id)
+			return
+		}
+		archetype.MoveWaste(waste, center.Lerp(target, driftSmoothing))
+		if location := gameData.WasteLocations[waste.Id]; location != nil {
+			location.Location = waste.Path
+		}
+	})
+}
@@ -1,10 +1,14 @@
 package system
 
 import (
+	"fmt"
 	"image/color"
+	"log"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/yohamta/donburi"
 	"github.com/yohamta/donburi/features/transform"
@@ -17,6 +21,12 @@ import (
 	"amaru/archetype"
 	"amaru/assets"
 	"amaru/component"
+	"amaru/net"
+)
+
+const (
+	networkPanelLineHeight = 18
+	networkPanelPadding    = 8
 )
 
 type Debug struct {
@@ -65,23 +75,97 @@ func (d *Debug) Update(w donburi.World) {
 	if inpututil.IsKeyJustPressed(ebiten.KeySlash) {
 		d.debug.Enabled = !d.debug.Enabled
 	}
+	if d.debug.Enabled && inpututil.IsKeyJustPressed(ebiten.KeyF9) && d.game.Session != nil && d.game.Session.RemoteClient != nil {
+		path := fmt.Sprintf("amaru-network-%s.json", time.Now().Format("20060102-150405"))
+		if err := d.game.Session.RemoteClient.Metrics.DumpFile(path); err != nil {
+			log.Println("unable to dump network metrics:", err)
+		} else {
+			log.Println("network metrics written to " + path)
+		}
+	}
+	if d.debug.Enabled && inpututil.IsKeyJustPressed(ebiten.KeyF8) && d.game.Session != nil && d.game.Session.RemoteClient != nil {
+		if d.game.Session.RemoteClient.Simulator.Toggle(net.BadNetwork) {
+			log.Println("network simulator enabled: " + net.BadNetwork.String())
+		} else {
+			log.Println("network simulator disabled")
+		}
+	}
 }
 
-func (d *Debug) drawBox(screen *ebiten.Image, shape *cp.Shape, clr color.Color) {
-	box := shape.Class.(*cp.PolyShape)
-	lineWidth := float32(5)
-
-	body := shape.Body()
+func formatBytes(bytes int) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	if bytes < 1024*1024 {
+		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
+	}
+	return fmt.Sprintf("%.1fMB", float64(bytes)/(1024*1024))
+}
 
-	for i := 0; i < 4; i++ {
-		vert1 := box.Vert(i)
-		vert2 := box.Vert((i + 1) % 4)
+func (d *Debug) networkLines(remoteClient *net.RemoteClient) []string {
+	totals, peers := remoteClient.Metrics.Snapshot()
+	lines := []string{
+		fmt.Sprintf("out %s (%d msg) superseded %d overflow %d dropped %d", formatBytes(totals.BytesOut), totals.MessagesOut, totals.Superseded, totals.Overflowed, totals.Dropped),
+	}
+	if conditions, enabled := remoteClient.Simulator.Conditions(); enabled {
+		lines = append(lines, "netsim "+conditions.String())
+	}
+	for _, peer := range peers {
+		name := peer.Name
+		if name == "" && len(peer.ID) > 8 {
+			name = peer.ID[:8]
+		}
+		lines = append(lines, fmt.Sprintf("%-10.10s rtt %4dms off %+5dms %4.1f/s in %s drop %d last %.2fs",
+			name,
+			peer.RTT.Milliseconds(),
+			peer.ClockOffset.Milliseconds(),
+			peer.UpdateRate,
+			formatBytes(peer.BytesIn),
+			peer.Dropped,
+			peer.SinceLastUpdate().Seconds(),
+		))
+	}
+	return lines
+}
 
-		// Convert the vertices to world coordinates
-		worldVert1 := body.LocalToWorld(vert1)
-		worldVert2 := body.LocalToWorld(vert2)
+func (d *Debug) drawNetworkPanel(screen *ebiten.Image) {
+	if d.game == nil || d.game.Session == nil || d.game.Session.RemoteClient == nil {
+		return
+	}
+	lines := d.networkLines(d.game.Session.RemoteClient)
+	width := 0
+	for _, line := range lines {
+		if lineWidth := text.BoundString(assets.MainFont, line).Dx(); lineWidth > width {
+			width = lineWidth
+		}
+	}
+	x := float32(d.game.Settings.ScreenWidth - width - 3*networkPanelPadding)
+	y := float32(networkPanelPadding)
+	height := float32(len(lines)*networkPanelLineHeight + networkPanelPadding)
+	vector.DrawFilledRect(screen, x, y, float32(width+2*networkPanelPadding), height, color.RGBA{A: 160}, false)
+	for i, line := range lines {
+		text.Draw(screen, line, assets.MainFont, int(x)+networkPanelPadding, int(y)+(i+1)*networkPanelLineHeight, colornames.White)
+	}
+}
 
-		vector.StrokeLine(screen, float32(worldVert1.X), float32(worldVert1.Y+32), float32(worldVert2.X), float32(worldVert2.Y+32), lineWidth, clr, false)
+func (d *Debug) drawBox(screen *ebiten.Image, shape *cp.Shape, clr color.Color) {
+	lineWidth := float32(5)
+	switch class := shape.Class.(type) {
+	case *cp.Circle:
+		center := class.TransformC()
+		vector.StrokeCircle(screen, float32(center.X), float32(center.Y+32), float32(class.Radius()), lineWidth, clr, false)
+	case *cp.Segment:
+		a, b := class.TransformA(), class.TransformB()
+		vector.StrokeLine(screen, float32(a.X), float32(a.Y+32), float32(b.X), float32(b.Y+32), lineWidth, clr, false)
+	case *cp.PolyShape:
+		count := class.Count()
+		for i := 0; i < count; i++ {
+			// transformed vertices are already in world coordinates
+			vert1 := class.TransformVert(i)
+			vert2 := class.TransformVert((i + 1) % count)
+
+			vector.StrokeLine(screen, float32(vert1.X), float32(vert1.Y+32), float32(vert2.X), float32(vert2.Y+32), lineWidth, clr, false)
+		}
 	}
 }
 
@@ -95,8 +179,8 @@ func (d *Debug) Draw(w donburi.World, screen *ebiten.Image) {
 	if d.debug.Enabled {
 		boxColor := colornames.Lime
 		for _, shape := range d.debug.Shapes {
-			if shape.UserData != nil {
-				entry := shape.UserData.(*donburi.Entry)
+			// hazards keep their zone in the user data
+			if entry, ok := shape.UserData.(*donburi.Entry); ok {
 				if !w.Valid(entry.Entity()) {
 					continue
 				}
@@ -123,4 +207,6 @@ func (d *Debug) Draw(w donburi.World, screen *ebiten.Image) {
 	op = &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-cameraPos.X, -cameraPos.Y)
 	screen.DrawImage(d.offscreen, op)
+
+	d.drawNetworkPanel(screen)
 }
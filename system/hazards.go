@@ -0,0 +1,185 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"context"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/features/transform"
+)
+
+const (
+	// frames each whirlpool frame is shown
+	whirlpoolFrameRate = 6
+	// frames between two storm updates of the host when nothing changes
+	stormSendFrames = 60
+	// how fast the storm darkness comes and goes
+	stormShadeStep = 0.04
+)
+
+var stormShadeColor = color.RGBA{R: 6, G: 8, B: 18, A: 230}
+
+// Hazards animates the whirlpools and runs the storms, the host starts and
+// stops them and peers follow. Boats inside a storm only see around them.
+type Hazards struct {
+	game          *component.GameData
+	hazards       *component.HazardsData
+	timers        []*engine.Timer
+	stormMessages *engine.Queue[net.StormMessage]
+	shadow        *ebiten.Image
+	light         *ebiten.Image
+	shade         float64
+	frames        int
+	initialized   bool
+}
+
+func NewHazards() *Hazards {
+	return &Hazards{
+		stormMessages: engine.NewQueue[net.StormMessage](),
+	}
+}
+
+func (h *Hazards) Update(w donburi.World) {
+	if h.game == nil {
+		h.game = component.MustFindGame(w)
+		if h.game == nil {
+			return
+		}
+	}
+	if h.hazards == nil {
+		h.hazards = component.FindHazards(w)
+		if h.hazards == nil {
+			return
+		}
+	}
+	gameData := h.game.Session.RemoteClient.GameData
+	if !h.initialized {
+		h.initialized = true
+		h.game.Session.RemoteClient.RemoteStorm.AddListener(func(ctx context.Context, rsm net.RemoteStormMessage) {
+			h.stormMessages.Add(&rsm.Msg)
+		})
+		for _, storm := range h.hazards.Storms {
+			h.timers = append(h.timers, engine.NewTimer(stormSeconds(storm.Zone.Interval)))
+		}
+		// peers joining mid round get the storms already blowing
+		if gameData.Storms != nil {
+			archetype.SetStorms(h.hazards, gameData.Storms)
+		}
+	}
+	h.frames++
+
+	if h.frames%whirlpoolFrameRate == 0 {
+		for _, whirlpool := range h.hazards.Whirlpools {
+			whirlpool.Frame = (whirlpool.Frame + 1) % len(whirlpool.Frames)
+			component.Sprite.Get(whirlpool.Sprite).Image = whirlpool.Frames[whirlpool.Frame]
+		}
+	}
+
+	if h.game.Session.Type == component.SessionTypeHost {
+		h.runStorms()
+	}
+	for h.stormMessages.Length() > 0 {
+		message := h.stormMessages.Remove()
+		if h.game.Session.Type == component.SessionTypeHost {
+			continue
+		}
+		gameData.Storms = message.Active
+		archetype.SetStorms(h.hazards, message.Active)
+	}
+}
+
+func (h *Hazards) runStorms() {
+	if len(h.hazards.Storms) == 0 {
+		return
+	}
+	changed := false
+	for i, storm := range h.hazards.Storms {
+		// storms without a duration never blow
+		if storm.Zone.Duration <= 0 {
+			continue
+		}
+		timer := h.timers[i]
+		timer.Update()
+		if !timer.IsReady() {
+			continue
+		}
+		storm.Active = !storm.Active
+		if storm.Active {
+			h.timers[i] = engine.NewTimer(stormSeconds(storm.Zone.Duration))
+		} else {
+			h.timers[i] = engine.NewTimer(stormSeconds(storm.Zone.Interval))
+		}
+		changed = true
+	}
+	if !changed && h.frames%stormSendFrames != 0 {
+		return
+	}
+	active := h.hazards.StormsActive()
+	h.game.Session.RemoteClient.GameData.Storms = active
+	archetype.SetStorms(h.hazards, active)
+	h.game.Session.RemoteClient.SendStormMessage(active)
+}
+
+func (h *Hazards) Draw(w donburi.World, screen *ebiten.Image) {
+	if h.hazards == nil || len(h.hazards.Storms) == 0 {
+		return
+	}
+	player, _ := archetype.MustFindLocalPlayer(w)
+	inStorm := player.StormFrames > 0 && player.Storm != nil && player.Storm.Active
+	if inStorm {
+		h.shade = math.Min(h.shade+stormShadeStep, 1)
+	} else {
+		h.shade = math.Max(h.shade-stormShadeStep, 0)
+	}
+	if h.shade == 0 {
+		return
+	}
+	if h.shadow == nil {
+		h.shadow = ebiten.NewImage(h.game.Settings.ScreenWidth, h.game.Settings.ScreenHeight)
+		h.light = stormLight(component.StormVisibility)
+	}
+
+	camera := archetype.MustFindCamera(w)
+	cameraPos := transform.Transform.Get(camera).LocalPosition
+	position := player.Body.Position()
+	// the boat is drawn 32 pixels below its body
+	x := position.X - cameraPos.X
+	y := position.Y + 32 - cameraPos.Y
+
+	h.shadow.Fill(stormShadeColor)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x-component.StormVisibility, y-component.StormVisibility)
+	op.Blend = ebiten.BlendDestinationOut
+	h.shadow.DrawImage(h.light, op)
+
+	op = &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(float32(h.shade))
+	screen.DrawImage(h.shadow, op)
+}
+
+// stormLight is the circle cut out of the storm darkness around the boat,
+// it fades out towards the border.
+func stormLight(radius float64) *ebiten.Image {
+	size := int(radius * 2)
+	dc := gg.NewContext(size, size)
+	gradient := gg.NewRadialGradient(radius, radius, 0, radius, radius, radius)
+	gradient.AddColorStop(0, color.White)
+	gradient.AddColorStop(0.6, color.White)
+	gradient.AddColorStop(1, color.Transparent)
+	dc.SetFillStyle(gradient)
+	dc.DrawCircle(radius, radius, radius)
+	dc.Fill()
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+func stormSeconds(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
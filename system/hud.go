@@ -88,10 +88,12 @@ func (h *HUD) Update(w donburi.World) {
 	if h.hudUi.Close {
 		h.hudUi.Close = false
 		h.game.Session.End = true
-		go h.game.Session.RemoteClient.Client.Close()
+		go h.game.Session.RemoteClient.Close()
 	}
 	if h.hudUi.Audio {
 		h.game.Muted = !h.game.Muted
+		component.CurrentProfile.Muted = h.game.Muted
+		component.CurrentProfile.Save()
 		h.hudUi.Audio = false
 	}
 	archetype.UpdateCursorImage(h.game.CursorOverButton)
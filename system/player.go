@@ -3,7 +3,9 @@ package system
 import (
 	"amaru/archetype"
 	"amaru/component"
+	"amaru/engine"
 	"amaru/net"
+	"context"
 
 	"github.com/jakecoffman/cp"
 	"github.com/yohamta/donburi"
@@ -14,9 +16,12 @@ import (
 )
 
 type Player struct {
-	game  *component.GameData
-	query *query.Query
-	space *cp.Space
+	game     *component.GameData
+	query    *query.Query
+	space    *cp.Space
+	currents *component.CurrentsData
+	hazards  *component.HazardsData
+	bumps    *engine.Queue[net.BumpMessage]
 }
 
 func NewPlayer(space *cp.Space) *Player {
@@ -24,6 +29,7 @@ func NewPlayer(space *cp.Space) *Player {
 		query: query.NewQuery(filter.Contains(
 			component.Player,
 		)),
+		bumps: engine.NewQueue[net.BumpMessage](),
 	}
 }
 
@@ -40,6 +46,21 @@ func (p *Player) Update(w donburi.World) {
 			return
 		}
 		p.space = physics.Space
+		p.game.Session.RemoteClient.RemoteBump.AddListener(func(ctx context.Context, rbm net.RemoteBumpMessage) {
+			p.bumps.Add(&rbm.Msg)
+		})
+	}
+	if p.currents == nil {
+		p.currents = component.FindCurrents(w)
+	}
+	if p.hazards == nil {
+		p.hazards = component.FindHazards(w)
+	}
+	for p.bumps.Length() > 0 {
+		bump := p.bumps.Remove()
+		one, _ := archetype.FindPlayerByID(w, bump.One)
+		other, _ := archetype.FindPlayerByID(w, bump.Other)
+		archetype.ApplyBump(p.game, one, other, *bump)
 	}
 
 	p.query.Each(w, func(entry *donburi.Entry) {
@@ -56,6 +77,10 @@ func (p *Player) Update(w donburi.World) {
 				return
 			}
 		}
+		tickHazards(player)
+		tickBump(player)
+		archetype.UpdateBoat(p.game, player)
+		archetype.UpdateTeamColors(p.game, entry, player)
 		if player.Local {
 			p.updateLocalPlayer(w, entry, player)
 		} else {
@@ -67,30 +92,34 @@ func (p *Player) Update(w donburi.World) {
 
 func (p *Player) updateRemotePlayer(w donburi.World, entry *donburi.Entry, player *component.PlayerData) {
 	if p.game.Session.PlayerMessage[player.ID] == nil {
-		if player.PlayerCollision {
-			player.Body.SetVelocityVector(cp.Vector{X: 0, Y: 0})
-			player.PlayerCollision = false
-		}
+		// idle boats drift with the currents the same way on every peer
+		player.Body.SetVelocityVector(p.drift(player))
+		pos := player.Body.Position()
+		transform.Transform.Get(entry).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
+		transform.Transform.Get(player.Label).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
 		return
 	}
-	player.PlayerCollision = false
 	message := *p.game.Session.PlayerMessage[player.ID]
 
 	vector := cp.Vector{
 		X: message.Vector.X,
 		Y: message.Vector.Y,
 	}
+	sprite := component.Sprite.Get(entry)
 
 	if message.Position != nil {
 		animation := archetype.ActionsByKey[message.Animation]
 		component.AnimationComponent.Get(entry).SelectAnimationByAction(animation)
 		newPosition := cp.Vector{X: float64(message.Position.X), Y: float64(message.Position.Y)}
+		// the host does not trust reported moves
+		newPosition, vector = archetype.ValidateMove(w, p.game, player, newPosition, vector, float64(sprite.Image.Bounds().Dx()))
 
 		player.Body.SetPosition(newPosition)
 		p.game.Session.PlayerMessage[player.ID].Position = nil
+		p.game.Session.PlayerMessage[player.ID].Vector = net.Point{X: vector.X, Y: vector.Y}
 	}
-	if message.Vector.X == 0 && message.Vector.Y == 0 {
-		player.Body.SetVelocityVector(cp.Vector{X: 0, Y: 0})
+	if vector.X == 0 && vector.Y == 0 {
+		player.Body.SetVelocityVector(p.drift(player))
 		pos := player.Body.Position()
 		transform.Transform.Get(entry).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
 		transform.Transform.Get(player.Label).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
@@ -98,9 +127,8 @@ func (p *Player) updateRemotePlayer(w donburi.World, entry *donburi.Entry, playe
 		return
 	}
 
-	sprite := component.Sprite.Get(entry)
 	newVelocity := cp.Vector{X: float64(vector.X) * float64(sprite.Image.Bounds().Dx()), Y: float64(vector.Y) * float64(sprite.Image.Bounds().Dx())}
-	player.Body.SetVelocityVector(newVelocity)
+	player.Body.SetVelocityVector(steer(player, newVelocity.Add(p.drift(player))))
 
 	pos := player.Body.Position()
 	transform.Transform.Get(entry).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
@@ -108,7 +136,6 @@ func (p *Player) updateRemotePlayer(w donburi.World, entry *donburi.Entry, playe
 }
 
 func (p *Player) updateLocalPlayer(w donburi.World, entry *donburi.Entry, player *component.PlayerData) {
-	player.PlayerCollision = false
 	if player.Collision {
 		player.Body.SetVelocityVector(cp.Vector{X: 0, Y: 0})
 
@@ -118,13 +145,13 @@ func (p *Player) updateLocalPlayer(w donburi.World, entry *donburi.Entry, player
 			pos := transform.Transform.Get(entry).LocalPosition
 			anim := component.AnimationComponent.Get(entry).CurrentAnimation
 			if anim != nil && changed {
-				go p.game.Session.RemoteClient.SendMessage(net.Point{X: 0, Y: 0}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
+				p.game.Session.RemoteClient.SendMessage(net.Point{X: 0, Y: 0}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
 			}
 			var animname *string
 			if anim != nil {
 				animname = &anim.Name
 			}
-			go p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X, Y: pos.Y}, animname)
+			p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X, Y: pos.Y}, animname)
 			return
 		}
 		dir := vector.Dot(*player.LastDirection)
@@ -136,13 +163,13 @@ func (p *Player) updateLocalPlayer(w donburi.World, entry *donburi.Entry, player
 			pos := transform.Transform.Get(entry).LocalPosition
 			anim := component.AnimationComponent.Get(entry).CurrentAnimation
 			if anim != nil && changed {
-				go p.game.Session.RemoteClient.SendMessage(net.Point{X: 0, Y: 0}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
+				p.game.Session.RemoteClient.SendMessage(net.Point{X: 0, Y: 0}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
 			}
 			var animname *string
 			if anim != nil {
 				animname = &anim.Name
 			}
-			go p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X, Y: pos.Y}, animname)
+			p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X, Y: pos.Y}, animname)
 			return
 		}
 	}
@@ -153,19 +180,97 @@ func (p *Player) updateLocalPlayer(w donburi.World, entry *donburi.Entry, player
 	if vector.X != 0 || vector.Y != 0 {
 		player.LastDirection = &cp.Vector{X: vector.X, Y: vector.Y}
 	}
-	vector = vector.Mult(p.game.Speed + 5)
-	player.Body.SetVelocityVector(cp.Vector{X: float64(vector.X) * float64(sprite.Image.Bounds().Dx()), Y: float64(vector.Y) * float64(sprite.Image.Bounds().Dx())})
+	vector = vector.Mult((p.game.Speed + 5) * player.Hull.Speed)
+	slowed := player.SlowFrames > 0
+	if slowed {
+		player.SlowFrames--
+		vector = vector.Mult(player.SlowFactor)
+	}
+	boosted := player.HasPowerUp(component.PowerUpSpeed)
+	if boosted {
+		vector = vector.Mult(component.SpeedBoost)
+	}
+	stunned := player.StunFrames > 0
+	if stunned {
+		vector = cp.Vector{}
+	}
+	knocked := player.Knockback.Length() > 1
+	// tell the peers when the boat changes speed
+	changed = changed || slowed != player.Slowed || boosted != player.Boosted || stunned != player.Stunned || knocked != player.Knocked
+	player.Slowed = slowed
+	player.Boosted = boosted
+	player.Stunned = stunned
+	player.Knocked = knocked
+	velocity := cp.Vector{X: float64(vector.X) * float64(sprite.Image.Bounds().Dx()), Y: float64(vector.Y) * float64(sprite.Image.Bounds().Dx())}
+	player.Body.SetVelocityVector(steer(player, velocity.Add(p.drift(player))))
 
 	pos := player.Body.Position()
 	transform.Transform.Get(entry).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
 	transform.Transform.Get(player.Label).LocalPosition = math.Vec2{X: pos.X - 16, Y: pos.Y + 16}
 	anim := component.AnimationComponent.Get(entry).CurrentAnimation
 	if changed && anim != nil {
-		go p.game.Session.RemoteClient.SendMessage(net.Point{X: vector.X, Y: vector.Y}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
+		p.game.Session.RemoteClient.SendMessage(net.Point{X: vector.X, Y: vector.Y}, net.Point{X: pos.X, Y: pos.Y}, anim.Name)
 	}
 	var animname *string
 	if anim != nil {
 		animname = &anim.Name
 	}
-	go p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X - 16, Y: pos.Y + 16}, animname)
+	p.game.Session.RemoteClient.SetLocalPosition(&net.Point{X: pos.X - 16, Y: pos.Y + 16}, animname)
+}
+
+// drift is what carries the boat besides its own speed: the currents, the
+// whirlpools and the storms it is in and the bumps it took.
+func (p *Player) drift(player *component.PlayerData) cp.Vector {
+	position := player.Body.Position()
+	drift := p.currents.DriftAt(position).Add(player.Knockback)
+	if player.WhirlpoolFrames > 0 && player.Whirlpool != nil {
+		center := player.Whirlpool.Area.TetraCenter()
+		toCenter := cp.Vector{X: center.X, Y: center.Y}.Sub(position)
+		if toCenter.Length() > 1 {
+			pull := toCenter.Normalize()
+			drift = drift.Add(pull.Mult(player.Whirlpool.Pull)).Add(pull.Perp().Mult(player.Whirlpool.Spin))
+		}
+	}
+	if player.StormFrames > 0 && player.Storm != nil && player.Storm.Active {
+		wind := player.Storm.Zone.Direction
+		drift = drift.Add(cp.Vector{X: wind.X, Y: wind.Y}.Mult(player.Storm.Zone.Strength))
+	}
+	return drift
+}
+
+// tickHazards counts down the frames the hazards last, the colliders set
+// them again while the boat stays on the hazard.
+func tickHazards(player *component.PlayerData) {
+	if player.WhirlpoolFrames > 0 {
+		player.WhirlpoolFrames--
+	}
+	if player.StormFrames > 0 {
+		player.StormFrames--
+	}
+	if player.StunFrames > 0 {
+		player.StunFrames--
+	}
+	if player.StunCooldown > 0 {
+		player.StunCooldown--
+	}
+}
+
+// tickBump fades the knockback of the last bump.
+func tickBump(player *component.PlayerData) {
+	player.Knockback = player.Knockback.Mult(component.BumpDecay)
+	if player.Knockback.Length() < 1 {
+		player.Knockback = cp.Vector{}
+	}
+	if player.BumpCooldown > 0 {
+		player.BumpCooldown--
+	}
+	if player.BumpFrames > 0 {
+		player.BumpFrames--
+	}
+}
+
+// steer moves the boat velocity towards the target as fast as its hull
+// handles, peers steer remote boats the same way.
+func steer(player *component.PlayerData, target cp.Vector) cp.Vector {
+	return player.Body.Velocity().Lerp(target, player.Hull.Handling)
 }
@@ -0,0 +1,105 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"fmt"
+	"time"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+type pollutionSource struct {
+	source  assets.PollutionSource
+	timer   *engine.Timer
+	spawned int
+}
+
+// Pollution spawns waste from the pollution sources of the level. Only the
+// host runs it, peers receive the new waste locations.
+type Pollution struct {
+	game    *component.GameData
+	space   *cp.Space
+	debug   *component.DebugData
+	sources []*pollutionSource
+}
+
+func NewPollution(space *cp.Space, level *assets.Level) *Pollution {
+	pollution := &Pollution{
+		space: space,
+	}
+	for _, source := range level.PollutionSources {
+		if source.Interval <= 0 {
+			continue
+		}
+		pollution.sources = append(pollution.sources, &pollutionSource{
+			source: source,
+			timer:  engine.NewTimer(time.Duration(source.Interval * float64(time.Second))),
+		})
+	}
+	return pollution
+}
+
+func (p *Pollution) Update(w donburi.World) {
+	if p.game == nil {
+		p.game = component.MustFindGame(w)
+		if p.game == nil {
+			return
+		}
+	}
+	if p.game.Session.Type != component.SessionTypeHost || p.game.GameOver {
+		return
+	}
+	if p.debug == nil {
+		debug, ok := query.NewQuery(filter.Contains(component.Debug)).First(w)
+		if !ok {
+			return
+		}
+		p.debug = component.Debug.Get(debug)
+	}
+	gameData := p.game.Session.RemoteClient.GameData
+	if !gameData.OnGameState || gameData.Counter <= 0 {
+		return
+	}
+	pressure := gameData.PollutionPressure()
+	spawned := []net.WasteLocation{}
+	for _, source := range p.sources {
+		if source.source.Max > 0 && source.spawned >= source.source.Max {
+			continue
+		}
+		// falling behind makes the timers run faster
+		for i := 0; i < int(pressure); i++ {
+			source.timer.Update()
+		}
+		if pressure-float64(int(pressure)) > engine.RandomRange(0, 1) {
+			source.timer.Update()
+		}
+		if !source.timer.IsReady() {
+			continue
+		}
+		source.timer.Reset()
+		id := fmt.Sprintf("s%d", len(gameData.WasteLocations))
+		waste := archetype.SpawnWasteInArea(w, p.space, p.debug, id, source.source.Area)
+		if waste == nil {
+			continue
+		}
+		source.spawned++
+		location := &net.WasteLocation{
+			Id:       id,
+			Type:     waste.Type.Name,
+			Location: waste.Path,
+		}
+		gameData.WasteLocations[id] = location
+		spawned = append(spawned, *location)
+	}
+	if len(spawned) > 0 {
+		p.game.WasteSize = len(gameData.WasteLocations)
+		p.game.Session.RemoteClient.SendWasteSpawnMessage(spawned)
+	}
+}
@@ -0,0 +1,272 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/engine"
+	"amaru/net"
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+const (
+	// free spots tried before the host skips a power-up spawn
+	powerUpAttempts = 10
+	// frames between two updates of the waste pulled by magnets
+	magnetSendFrames = 15
+)
+
+// PowerUps places power-ups on the host, replicates the pickups to the peers
+// and runs the effects of the active ones.
+type PowerUps struct {
+	game          *component.GameData
+	space         *cp.Space
+	debug         *component.DebugData
+	playerQuery   *query.Query
+	powerUpQuery  *query.Query
+	wasteQuery    *query.Query
+	spawnMessages *engine.Queue[net.PowerUpSpawnMessage]
+	claimMessages *engine.Queue[net.PowerUpClaimMessage]
+	spawnTimer    *engine.Timer
+	pulled        map[string]net.Point
+	mapWidth      float64
+	mapHeight     float64
+	frames        int
+	initialized   bool
+}
+
+func NewPowerUps(level *assets.Level) *PowerUps {
+	return &PowerUps{
+		playerQuery:   query.NewQuery(filter.Contains(component.Player)),
+		powerUpQuery:  query.NewQuery(filter.Contains(component.PowerUp)),
+		wasteQuery:    query.NewQuery(filter.Contains(component.Waste)),
+		spawnMessages: engine.NewQueue[net.PowerUpSpawnMessage](),
+		claimMessages: engine.NewQueue[net.PowerUpClaimMessage](),
+		spawnTimer:    engine.NewTimer(component.PowerUpInterval * time.Second),
+		pulled:        map[string]net.Point{},
+		mapWidth:      float64(level.Background.Bounds().Dx()),
+		mapHeight:     float64(level.Background.Bounds().Dy()),
+	}
+}
+
+func (p *PowerUps) Update(w donburi.World) {
+	if p.game == nil {
+		p.game = component.MustFindGame(w)
+		if p.game == nil {
+			return
+		}
+	}
+	if p.space == nil {
+		physics, _ := archetype.MustFindPhysics(w)
+		if physics == nil {
+			return
+		}
+		p.space = physics.Space
+	}
+	if p.debug == nil {
+		debug, ok := query.NewQuery(filter.Contains(component.Debug)).First(w)
+		if !ok {
+			return
+		}
+		p.debug = component.Debug.Get(debug)
+	}
+	gameData := p.game.Session.RemoteClient.GameData
+	if !p.initialized {
+		p.initialized = true
+		p.game.Session.RemoteClient.RemotePowerUpSpawn.AddListener(func(ctx context.Context, rpsm net.RemotePowerUpSpawnMessage) {
+			p.spawnMessages.Add(&rpsm.Msg)
+		})
+		p.game.Session.RemoteClient.RemotePowerUpClaim.AddListener(func(ctx context.Context, rpcm net.RemotePowerUpClaimMessage) {
+			p.claimMessages.Add(&rpcm.Msg)
+		})
+		// peers joining mid round get the power-ups already placed
+		if p.game.Session.Type != component.SessionTypeHost {
+			for _, location := range gameData.PowerUps {
+				archetype.PlacePowerUp(w, p.space, p.debug, location)
+			}
+		}
+	}
+	if gameData.PowerUps == nil {
+		gameData.PowerUps = map[string]*net.PowerUpLocation{}
+	}
+
+	if p.game.Session.Type == component.SessionTypeHost {
+		p.spawn(w)
+	}
+	for p.spawnMessages.Length() > 0 {
+		message := p.spawnMessages.Remove()
+		for i := range message.PowerUps {
+			location := message.PowerUps[i]
+			if gameData.PowerUps[location.Id] != nil {
+				continue
+			}
+			gameData.PowerUps[location.Id] = &location
+			archetype.PlacePowerUp(w, p.space, p.debug, &location)
+		}
+	}
+	for p.claimMessages.Length() > 0 {
+		message := p.claimMessages.Remove()
+		p.claim(w, message)
+	}
+
+	p.playerQuery.Each(w, func(entry *donburi.Entry) {
+		player := component.Player.Get(entry)
+		if player == nil {
+			return
+		}
+		for kind := range player.PowerUps {
+			player.PowerUps[kind]--
+			if player.PowerUps[kind] <= 0 {
+				delete(player.PowerUps, kind)
+			}
+		}
+		// wide hulls reach plain waste around them, nets reach everything light
+		if radius := player.Hull.Reach; radius > 0 || player.HasPowerUp(component.PowerUpNet) {
+			if player.HasPowerUp(component.PowerUpNet) && component.NetRadius > radius {
+				radius = component.NetRadius
+			}
+			p.sweep(w, player, radius)
+		}
+		if player.HasPowerUp(component.PowerUpMagnet) && p.game.Session.Type == component.SessionTypeHost {
+			p.pull(w, player)
+		}
+	})
+
+	p.frames++
+	if p.frames%magnetSendFrames == 0 && len(p.pulled) > 0 {
+		p.game.Session.RemoteClient.SendWasteDriftMessage(p.pulled)
+		p.pulled = map[string]net.Point{}
+	}
+}
+
+func (p *PowerUps) spawn(w donburi.World) {
+	gameData := p.game.Session.RemoteClient.GameData
+	if !gameData.OnGameState || gameData.Counter <= 0 || p.game.GameOver {
+		return
+	}
+	p.spawnTimer.Update()
+	if !p.spawnTimer.IsReady() {
+		return
+	}
+	p.spawnTimer.Reset()
+	waiting := 0
+	for _, location := range gameData.PowerUps {
+		if !location.Collected {
+			waiting++
+		}
+	}
+	if waiting >= component.MaxPowerUps {
+		return
+	}
+	for attempt := 0; attempt < powerUpAttempts; attempt++ {
+		center := cp.Vector{
+			X: engine.RandomRange(32, p.mapWidth-32),
+			Y: engine.RandomRange(32, p.mapHeight-32),
+		}
+		free := true
+		p.space.BBQuery(cp.NewBBForExtents(center, 16, 16), cp.SHAPE_FILTER_ALL, func(shape *cp.Shape, data interface{}) {
+			// storms blow over everything else
+			if _, ok := shape.UserData.(*component.StormData); ok {
+				return
+			}
+			free = false
+		}, nil)
+		if !free {
+			continue
+		}
+		location := &net.PowerUpLocation{
+			Id:       fmt.Sprintf("p%d", len(gameData.PowerUps)),
+			Kind:     component.PowerUpKinds[rand.Intn(len(component.PowerUpKinds))],
+			Position: net.Point{X: center.X, Y: center.Y},
+		}
+		gameData.PowerUps[location.Id] = location
+		archetype.PlacePowerUp(w, p.space, p.debug, location)
+		p.game.Session.RemoteClient.SendPowerUpSpawnMessage([]net.PowerUpLocation{*location})
+		return
+	}
+}
+
+func (p *PowerUps) claim(w donburi.World, message *net.PowerUpClaimMessage) {
+	var player *component.PlayerData
+	p.playerQuery.Each(w, func(entry *donburi.Entry) {
+		if candidate := component.Player.Get(entry); candidate != nil && candidate.ID == message.By {
+			player = candidate
+		}
+	})
+	p.powerUpQuery.Each(w, func(entry *donburi.Entry) {
+		powerUp := component.PowerUp.Get(entry)
+		if powerUp.Id != message.Id || powerUp.Collected {
+			return
+		}
+		if player == nil {
+			powerUp.Collected = true
+			component.Sprite.Get(entry).Hidden = true
+			return
+		}
+		archetype.ClaimPowerUp(p.game, powerUp, player)
+	})
+}
+
+// sweep collects the waste in the radius around a boat, every peer does it
+// for all the boats the same way it does for the pickups.
+func (p *PowerUps) sweep(w donburi.World, player *component.PlayerData, radius float64) {
+	position := player.Body.Position()
+	net := player.HasPowerUp(component.PowerUpNet)
+	p.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		if waste.Collected || waste.Shape == nil || waste.Type.Effect == assets.WasteEffectHeavy {
+			return
+		}
+		// without a net oil slicks still have to be circled
+		if !net && waste.Type.Effect == assets.WasteEffectCircle {
+			return
+		}
+		if location := p.game.Session.RemoteClient.GameData.WasteLocations[waste.Id]; location == nil || location.Collected {
+			return
+		}
+		bb := waste.Shape.BB()
+		if bb.ClampVect(&position).Distance(position) > radius {
+			return
+		}
+		if !archetype.ValidatePickup(w, player, cp.BB{L: bb.L - radius, B: bb.B - radius, R: bb.R + radius, T: bb.T + radius}) {
+			return
+		}
+		archetype.CollectWaste(p.game, waste, []*component.PlayerData{player})
+	})
+}
+
+// pull moves the waste around a boat with a magnet towards it, the host sends
+// the new positions the same way it does for the currents.
+func (p *PowerUps) pull(w donburi.World, player *component.PlayerData) {
+	position := player.Body.Position()
+	gameData := p.game.Session.RemoteClient.GameData
+	p.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		if waste.Collected || waste.Shape == nil || waste.Type.Effect == assets.WasteEffectHeavy {
+			return
+		}
+		center := waste.Shape.Body().Position()
+		distance := center.Distance(position)
+		if distance > component.MagnetRadius || distance < 1 {
+			return
+		}
+		step := component.MagnetSpeed / 60
+		if step > distance {
+			step = distance
+		}
+		next := center.Add(position.Sub(center).Normalize().Mult(step))
+		archetype.MoveWaste(waste, next)
+		if location := gameData.WasteLocations[waste.Id]; location != nil {
+			location.Location = waste.Path
+		}
+		p.pulled[waste.Id] = net.Point{X: next.X, Y: next.Y}
+	})
+}
@@ -25,6 +25,7 @@ type RemoteSystem struct {
 	sessionLeaveMessages    *engine.Queue[net.SessionLeaveMessage]
 	sessionJoinMessages     *engine.Queue[net.SessionJoinMessage]
 	initialPositionMessages *engine.Queue[net.RemoteInitialPositionMessage]
+	wasteSpawnMessages      *engine.Queue[net.WasteSpawnMessage]
 	startTime               time.Time
 }
 
@@ -36,6 +37,7 @@ func NewRemoteSystem() *RemoteSystem {
 		sessionLeaveMessages:    engine.NewQueue[net.SessionLeaveMessage](),
 		sessionJoinMessages:     engine.NewQueue[net.SessionJoinMessage](),
 		initialPositionMessages: engine.NewQueue[net.RemoteInitialPositionMessage](),
+		wasteSpawnMessages:      engine.NewQueue[net.WasteSpawnMessage](),
 		startTime:               time.Now(),
 	}
 }
@@ -88,6 +90,9 @@ func (s *RemoteSystem) Initialize(game *component.GameData, world donburi.World)
 	s.game.Session.RemoteClient.RemoteInitialPositionData.AddListener(func(ctx context.Context, ripd net.RemoteInitialPositionMessage) {
 		s.initialPositionMessages.Add(&ripd)
 	})
+	s.game.Session.RemoteClient.RemoteWasteSpawn.AddListener(func(ctx context.Context, rwsm net.RemoteWasteSpawnMessage) {
+		s.wasteSpawnMessages.Add(&rwsm.Msg)
+	})
 
 	if !s.game.Session.RemoteClient.Host && s.game.Session.JustJoined {
 		s.game.Session.JustJoined = false
@@ -115,7 +120,7 @@ func (s *RemoteSystem) Initialize(game *component.GameData, world donburi.World)
 		for _, loc := range s.game.Session.RemoteClient.GameData.WasteLocations {
 			s.game.Session.RemoteClient.GameData.WasteLocations[loc.Id] = loc
 			if !loc.Collected {
-				archetype.PlaceRemoteWasteFromPath(world, s.space, s.debug, loc.Id, loc.Location, loc.Collected)
+				archetype.PlaceRemoteWasteFromPath(world, s.space, s.debug, loc.Id, loc.Type, loc.Location, loc.Collected)
 			}
 		}
 
@@ -152,8 +157,20 @@ func (s *RemoteSystem) Update(w donburi.World) {
 	if s.shouldPlaceWaste && s.game.Session.RemoteClient.GameData.WasteLocations != nil {
 		s.shouldPlaceWaste = false
 		for _, loc := range s.game.Session.RemoteClient.GameData.WasteLocations {
-			archetype.PlaceRemoteWasteFromPath(w, s.space, s.debug, loc.Id, loc.Location, loc.Collected)
+			archetype.PlaceRemoteWasteFromPath(w, s.space, s.debug, loc.Id, loc.Type, loc.Location, loc.Collected)
+		}
+	}
+	for s.wasteSpawnMessages.Length() > 0 {
+		wsm := s.wasteSpawnMessages.Remove()
+		for i := range wsm.Locations {
+			loc := wsm.Locations[i]
+			if s.game.Session.RemoteClient.GameData.WasteLocations[loc.Id] != nil {
+				continue
+			}
+			s.game.Session.RemoteClient.GameData.WasteLocations[loc.Id] = &loc
+			archetype.PlaceRemoteWasteFromPath(w, s.space, s.debug, loc.Id, loc.Type, loc.Location, loc.Collected)
 		}
+		s.game.WasteSize = len(s.game.Session.RemoteClient.GameData.WasteLocations)
 	}
 	if s.shouldEnd {
 		s.game.Session.End = true
@@ -202,6 +219,8 @@ func (s *RemoteSystem) addPlayer(w donburi.World, sjm *net.SessionJoinMessage) {
 	if *s.game.Session.RemoteClient.Client.Id == sjm.Target {
 		return
 	}
+	// show our boat to the new player
+	s.game.Session.RemoteClient.SendBoatMessage(component.CurrentProfile.Hull, component.CurrentProfile.Tint)
 	participant := net.SessionParticipant{
 		Id:       sjm.Target,
 		Name:     sjm.Client.Participants[sjm.Target],
@@ -20,11 +20,20 @@ import (
 )
 
 type Render struct {
-	query      *query.Query
-	labelQuery *query.Query
-	offscreen  *ebiten.Image
-	game       *component.GameData
-	debug      *component.DebugData
+	query       *query.Query
+	labelQuery  *query.Query
+	playerQuery *query.Query
+	offscreen   *ebiten.Image
+	game        *component.GameData
+	debug       *component.DebugData
+	// weather of the round, menus have none
+	weather     *component.WeatherData
+	weatherSeed int64
+	night       *ebiten.Image
+	fog         *ebiten.Image
+	glow        *ebiten.Image
+	cone        *ebiten.Image
+	headings    map[string]float64
 }
 
 func NewRenderer() *Render {
@@ -36,7 +45,9 @@ func NewRenderer() *Render {
 		labelQuery: query.NewQuery(
 			filter.Contains(transform.Transform, component.PlayerLabel),
 		),
-		offscreen: ebiten.NewImage(level.Background.Bounds().Dx(), level.Background.Bounds().Dy()),
+		playerQuery: query.NewQuery(filter.Contains(component.Player)),
+		headings:    map[string]float64{},
+		offscreen:   ebiten.NewImage(level.Background.Bounds().Dx(), level.Background.Bounds().Dy()),
 	}
 }
 
@@ -47,6 +58,7 @@ func (r *Render) Update(w donburi.World) {
 			return
 		}
 	}
+	r.updateWeather(w)
 	if r.debug == nil {
 		debug, ok := query.NewQuery(filter.Contains(component.Debug)).First(w)
 		if !ok {
@@ -108,7 +120,9 @@ func (r *Render) Draw(w donburi.World, screen *ebiten.Image) {
 			op.GeoM.Translate(halfW, halfH)
 
 			colormm := colorm.ColorM{}
-			if sprite.ColorOverride != nil {
+			if sprite.ColorOverride != nil && sprite.ColorOverride.Tint {
+				colormm.Scale(sprite.ColorOverride.R, sprite.ColorOverride.G, sprite.ColorOverride.B, sprite.ColorOverride.A)
+			} else if sprite.ColorOverride != nil {
 				colormm.Scale(0, 0, 0, sprite.ColorOverride.A)
 				colormm.Translate(sprite.ColorOverride.R, sprite.ColorOverride.G, sprite.ColorOverride.B, 0)
 			}
@@ -118,6 +132,9 @@ func (r *Render) Draw(w donburi.World, screen *ebiten.Image) {
 			colorm.DrawImage(r.offscreen, sprite.Image, colormm, op)
 		}
 	}
+	if r.weather != nil {
+		r.drawNight(w)
+	}
 
 	r.labelQuery.Each(w, func(entry *donburi.Entry) {
 		position := transform.WorldPosition(entry)
@@ -143,5 +160,13 @@ func (r *Render) Draw(w donburi.World, screen *ebiten.Image) {
 
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-cameraPos.X, -cameraPos.Y)
+	if r.weather != nil {
+		red, green, blue := r.weather.Tint(component.RoundProgress(r.game.Session.RemoteClient.GameData))
+		op.ColorScale.Scale(float32(red), float32(green), float32(blue), 1)
+	}
 	screen.DrawImage(r.offscreen, op)
+	if r.weather != nil {
+		r.drawRain(screen)
+		r.drawFog(screen)
+	}
 }
@@ -109,7 +109,9 @@ func (r *UIRender) Draw(w donburi.World, screen *ebiten.Image) {
 			op.GeoM.Translate(halfW, halfH)
 
 			colormm := colorm.ColorM{}
-			if sprite.ColorOverride != nil {
+			if sprite.ColorOverride != nil && sprite.ColorOverride.Tint {
+				colormm.Scale(sprite.ColorOverride.R, sprite.ColorOverride.G, sprite.ColorOverride.B, sprite.ColorOverride.A)
+			} else if sprite.ColorOverride != nil {
 				colormm.Scale(0, 0, 0, sprite.ColorOverride.A)
 				colormm.Translate(sprite.ColorOverride.R, sprite.ColorOverride.G, sprite.ColorOverride.B, 0)
 			}
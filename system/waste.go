@@ -0,0 +1,86 @@
+package system
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	gomath "math"
+
+	"github.com/jakecoffman/cp"
+	"github.com/yohamta/donburi"
+	"github.com/yohamta/donburi/filter"
+	"github.com/yohamta/donburi/query"
+)
+
+// WasteEffects cleans the oil slicks the boats go around, every peer follows
+// all the boats the same way it does for the pickups.
+type WasteEffects struct {
+	game        *component.GameData
+	wasteQuery  *query.Query
+	playerQuery *query.Query
+}
+
+func NewWasteEffects() *WasteEffects {
+	return &WasteEffects{
+		wasteQuery:  query.NewQuery(filter.Contains(component.Waste)),
+		playerQuery: query.NewQuery(filter.Contains(component.Player)),
+	}
+}
+
+func (e *WasteEffects) Update(w donburi.World) {
+	if e.game == nil {
+		e.game = component.MustFindGame(w)
+		if e.game == nil {
+			return
+		}
+	}
+	e.wasteQuery.Each(w, func(entry *donburi.Entry) {
+		waste := component.Waste.Get(entry)
+		if waste.Collected || waste.Shape == nil || waste.Type.Effect != assets.WasteEffectCircle {
+			return
+		}
+		if location := e.game.Session.RemoteClient.GameData.WasteLocations[waste.Id]; location != nil && location.Collected {
+			waste.Collected = true
+			component.Sprite.Get(entry).Hidden = true
+			return
+		}
+		if waste.Circles == nil {
+			waste.Circles = map[string]*component.WasteCircle{}
+		}
+		center := waste.Shape.Body().Position()
+		e.playerQuery.Each(w, func(playerEntry *donburi.Entry) {
+			player := component.Player.Get(playerEntry)
+			if waste.Collected || player == nil || player.Body == nil {
+				return
+			}
+			offset := player.Body.Position().Sub(center)
+			// leaving the ring starts the lap over
+			if offset.Length() > waste.Type.CircleRadius {
+				delete(waste.Circles, player.ID)
+				return
+			}
+			angle := gomath.Atan2(offset.Y, offset.X)
+			circle := waste.Circles[player.ID]
+			if circle == nil {
+				waste.Circles[player.ID] = &component.WasteCircle{LastAngle: angle}
+				return
+			}
+			delta := angle - circle.LastAngle
+			if delta > gomath.Pi {
+				delta -= 2 * gomath.Pi
+			} else if delta < -gomath.Pi {
+				delta += 2 * gomath.Pi
+			}
+			circle.Swept += delta
+			circle.LastAngle = angle
+			if gomath.Abs(circle.Swept) < 2*gomath.Pi {
+				return
+			}
+			bb, radius := waste.Shape.BB(), waste.Type.CircleRadius
+			if !archetype.ValidatePickup(w, player, cp.BB{L: bb.L - radius, B: bb.B - radius, R: bb.R + radius, T: bb.T + radius}) {
+				return
+			}
+			archetype.CollectWaste(e.game, waste, []*component.PlayerData{player})
+		})
+	})
+}
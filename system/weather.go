@@ -0,0 +1,172 @@
+package system
+
+import (
+	"amaru/component"
+	"image/color"
+	"math"
+
+	"github.com/fogleman/gg"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/yohamta/donburi"
+)
+
+const (
+	// half the angle of the light cone in front of the boats
+	boatLightAngle = math.Pi / 6
+	// boats slower than this keep the heading they had
+	boatLightMinSpeed = 10.0
+)
+
+var (
+	nightColor = color.RGBA{R: 4, G: 8, B: 28, A: 255}
+	rainColor  = color.NRGBA{R: 170, G: 185, B: 215, A: 150}
+)
+
+// updateWeather follows the weather of the world, peers joining mid round
+// get the seed after the world is built.
+func (r *Render) updateWeather(w donburi.World) {
+	if r.weather == nil {
+		r.weather = component.FindWeather(w)
+		if r.weather == nil {
+			return
+		}
+	}
+	seed := r.game.Session.RemoteClient.GameData.WeatherSeed
+	if r.weather.Seed != seed {
+		*r.weather = component.NewWeather(seed)
+	}
+	if r.weatherSeed != seed || r.fog == nil {
+		r.weatherSeed = seed
+		r.fog = fogImage(r.weather, r.game.Settings.ScreenWidth, r.game.Settings.ScreenHeight)
+	}
+}
+
+// drawNight darkens the sea by the time of day and lights it up around the
+// boats and in front of them.
+func (r *Render) drawNight(w donburi.World) {
+	darkness := r.weather.Darkness(component.RoundProgress(r.game.Session.RemoteClient.GameData))
+	if darkness == 0 {
+		return
+	}
+	if r.night == nil {
+		r.night = ebiten.NewImage(r.offscreen.Bounds().Dx(), r.offscreen.Bounds().Dy())
+		r.glow = lightGlowImage(component.BoatLightRadius)
+		r.cone = lightConeImage(component.BoatLightLength)
+	}
+	r.night.Fill(nightColor)
+
+	r.playerQuery.Each(w, func(entry *donburi.Entry) {
+		player := component.Player.Get(entry)
+		if player.Body == nil {
+			return
+		}
+		position := player.Body.Position()
+		// the boat is drawn 32 pixels below its body
+		x, y := position.X, position.Y+32
+		if velocity := player.Body.Velocity(); velocity.Length() > boatLightMinSpeed {
+			r.headings[player.ID] = math.Atan2(velocity.Y, velocity.X)
+		}
+
+		op := &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendDestinationOut
+		op.GeoM.Translate(x-component.BoatLightRadius, y-component.BoatLightRadius)
+		r.night.DrawImage(r.glow, op)
+
+		op = &ebiten.DrawImageOptions{}
+		op.Blend = ebiten.BlendDestinationOut
+		op.GeoM.Translate(-component.BoatLightLength, -component.BoatLightLength)
+		op.GeoM.Rotate(r.headings[player.ID])
+		op.GeoM.Translate(x, y)
+		r.night.DrawImage(r.cone, op)
+	})
+
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleAlpha(float32(darkness))
+	r.offscreen.DrawImage(r.night, op)
+}
+
+// drawRain draws the drops over the screen, they fall the same way on every
+// peer.
+func (r *Render) drawRain(screen *ebiten.Image) {
+	rain := r.weather.RainAt(component.RoundProgress(r.game.Session.RemoteClient.GameData))
+	if rain == 0 {
+		return
+	}
+	width, height := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	frames := float64(r.game.Session.RemoteClient.GameData.Frames)
+	drops := int(float64(len(r.weather.Drops)) * rain)
+	for _, drop := range r.weather.Drops[:drops] {
+		x := wrapUnit(drop.X+r.weather.Wind*frames*0.002) * width
+		y := wrapUnit(drop.Y+frames*drop.Speed*0.02) * height
+		vector.StrokeLine(screen, float32(x), float32(y), float32(x+r.weather.Wind*6), float32(y+14), 1, rainColor, true)
+	}
+}
+
+// drawFog drifts the fog banks across the screen with the wind.
+func (r *Render) drawFog(screen *ebiten.Image) {
+	if r.weather.Fog == 0 || r.fog == nil {
+		return
+	}
+	width := float64(r.fog.Bounds().Dx())
+	offset := wrapUnit(r.weather.Wind*float64(r.game.Session.RemoteClient.GameData.Frames)*0.0005) * width
+	for _, x := range []float64{offset - width, offset} {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x, 0)
+		op.ColorScale.ScaleAlpha(float32(r.weather.Fog))
+		screen.DrawImage(r.fog, op)
+	}
+}
+
+func wrapUnit(value float64) float64 {
+	value = math.Mod(value, 1)
+	if value < 0 {
+		value++
+	}
+	return value
+}
+
+func lightGlowImage(radius float64) *ebiten.Image {
+	size := int(radius * 2)
+	dc := gg.NewContext(size, size)
+	gradient := gg.NewRadialGradient(radius, radius, 0, radius, radius, radius)
+	gradient.AddColorStop(0, color.White)
+	gradient.AddColorStop(1, color.Transparent)
+	dc.SetFillStyle(gradient)
+	dc.DrawCircle(radius, radius, radius)
+	dc.Fill()
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// lightConeImage points to the right from the center of the image.
+func lightConeImage(length float64) *ebiten.Image {
+	size := int(length * 2)
+	dc := gg.NewContext(size, size)
+	gradient := gg.NewRadialGradient(length, length, 0, length, length, length)
+	gradient.AddColorStop(0, color.White)
+	gradient.AddColorStop(1, color.Transparent)
+	dc.SetFillStyle(gradient)
+	dc.MoveTo(length, length)
+	dc.DrawArc(length, length, length, -boatLightAngle, boatLightAngle)
+	dc.ClosePath()
+	dc.Fill()
+	return ebiten.NewImageFromImage(dc.Image())
+}
+
+// fogImage draws the fog banks of the weather, it wraps around horizontally.
+func fogImage(weather *component.WeatherData, width, height int) *ebiten.Image {
+	dc := gg.NewContext(width, height)
+	for _, bank := range weather.Banks {
+		radius := float64(height) / 4 * bank.Size
+		for _, shift := range []float64{-float64(width), 0, float64(width)} {
+			x, y := bank.X*float64(width)+shift, bank.Y*float64(height)
+			gradient := gg.NewRadialGradient(x, y, 0, x, y, radius)
+			gradient.AddColorStop(0, color.NRGBA{R: 220, G: 225, B: 230, A: 200})
+			gradient.AddColorStop(1, color.Transparent)
+			dc.SetFillStyle(gradient)
+			dc.DrawCircle(x, y, radius)
+			dc.Fill()
+		}
+	}
+	return ebiten.NewImageFromImage(dc.Image())
+}
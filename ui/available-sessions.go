@@ -5,7 +5,12 @@ import (
 	"amaru/assets"
 	"amaru/component"
 	"amaru/net"
+	"fmt"
 	"image/color"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ebitenui/ebitenui"
 	"github.com/ebitenui/ebitenui/image"
@@ -16,20 +21,53 @@ import (
 )
 
 const (
-	menuSessions = "Available Sessions:"
+	menuSessions       = "Available Sessions:"
+	searchPlaceholder  = "Search"
+	sortByPingLabel    = "Sort: Ping"
+	sortByPlayersLabel = "Sort: Players"
+	sortByNameLabel    = "Sort: Name"
+	sessionsHeader     = "Host          Players  Level  Mode      Phase     Ping"
+
+	sessionsRefreshInterval = 5 * time.Second
 )
 
+type SessionSort int
+
+const (
+	SortByPing SessionSort = iota
+	SortByPlayers
+	SortByName
+)
+
+// sortLabels are cycled by the sort button in this order
+var sortLabels = []string{
+	SortByPing:    sortByPingLabel,
+	SortByPlayers: sortByPlayersLabel,
+	SortByName:    sortByNameLabel,
+}
+
 type AvailableSessionsMenu struct {
 	container      *widget.Container
 	Ui             *ebitenui.UI
 	cancelButton   *widget.Button
 	refreshButton  *widget.Button
 	joinButton     *widget.Button
+	sortButton     *widget.Button
+	searchInput    *widget.TextInput
 	sessions       *[]net.AvailableSession
+	refreshed      chan *[]net.AvailableSession
 	listLayoutData widget.RowLayoutData
-	list           *widget.List
+	list           *widget.Container
+	rows           *widget.Container
+	scroll         *widget.ScrollContainer
+	slider         *widget.Slider
+	rowButtons     map[string]*widget.Button
 	gameData       *component.GameData
 	shouldUpdate   bool
+	refreshing     bool
+	lastRefresh    time.Time
+	search         string
+	sortBy         SessionSort
 	Session        *net.AvailableSession
 	Done           bool
 	Cancelled      bool
@@ -40,8 +78,9 @@ func NewAvailableSessionsMenu(gameData *component.GameData) *AvailableSessionsMe
 		container: widget.NewContainer(
 			widget.ContainerOpts.Layout(widget.NewAnchorLayout()),
 		),
-		sessions: &[]net.AvailableSession{},
-		gameData: gameData,
+		sessions:  &[]net.AvailableSession{},
+		refreshed: make(chan *[]net.AvailableSession, 1),
+		gameData:  gameData,
 	}
 
 	parentContainer := widget.NewContainer(
@@ -68,7 +107,87 @@ func NewAvailableSessionsMenu(gameData *component.GameData) *AvailableSessionsMe
 	availableSessionsLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
 		HorizontalPosition: widget.AnchorLayoutPositionCenter,
 	}
-	entries := make([]any, 0, len(*availableSessionsMenu.sessions))
+	controlsContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(2),
+			widget.GridLayoutOpts.Spacing(10, 3),
+			widget.GridLayoutOpts.Stretch([]bool{true, false}, []bool{true}),
+		)),
+	)
+
+	availableSessionsMenu.searchInput = widget.NewTextInput(
+		widget.TextInputOpts.Image(archetype.CreateRoundedTextInputImages(200, 50, 5, colornames.White, colornames.Fuchsia, colornames.Grey, 5)),
+		widget.TextInputOpts.Placeholder(searchPlaceholder),
+		widget.TextInputOpts.Color(&widget.TextInputColor{
+			Idle:          assets.BlueColor,
+			Disabled:      colornames.Grey,
+			Caret:         colornames.Gray,
+			DisabledCaret: colornames.Grey,
+		}),
+		widget.TextInputOpts.Face(assets.MainFont),
+		widget.TextInputOpts.CaretOpts(
+			widget.CaretOpts.Color(colornames.Gray),
+			widget.CaretOpts.Size(assets.MainFont, 16),
+		),
+		widget.TextInputOpts.ChangedHandler(func(args *widget.TextInputChangedEventArgs) {
+			availableSessionsMenu.search = strings.ToLower(strings.TrimSpace(args.TextInput.GetText()))
+			availableSessionsMenu.shouldUpdate = availableSessionsMenu.sessions != nil
+		}),
+		widget.TextInputOpts.Padding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.TextInputOpts.RepeatInterval(150*time.Millisecond),
+	)
+	controlsContainer.AddChild(availableSessionsMenu.searchInput)
+
+	availableSessionsMenu.sortButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(sortByPingLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			availableSessionsMenu.sortBy = (availableSessionsMenu.sortBy + 1) % SessionSort(len(sortLabels))
+			args.Button.Text().Label = sortLabels[availableSessionsMenu.sortBy]
+			availableSessionsMenu.shouldUpdate = availableSessionsMenu.sessions != nil
+			archetype.PlayButtonClickAudio()
+		}),
+	)
+	controlsContainer.AddChild(availableSessionsMenu.sortButton)
+
+	sessionsHeaderLabel := widget.NewLabel(widget.LabelOpts.Text(sessionsHeader, assets.MainFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+
+	// the rows are plain buttons instead of a widget.List so full sessions can
+	// be greyed out one by one
+	availableSessionsMenu.rows = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionVertical))),
+	)
+	availableSessionsMenu.scroll = widget.NewScrollContainer(
+		widget.ScrollContainerOpts.Content(availableSessionsMenu.rows),
+		widget.ScrollContainerOpts.StretchContentWidth(),
+		widget.ScrollContainerOpts.Image(&widget.ScrollContainerImage{
+			Idle:     image.NewNineSliceColor(color.White),
+			Disabled: image.NewNineSliceColor(color.White),
+			Mask:     image.NewNineSliceColor(color.White),
+		}),
+	)
 	track := archetype.NewColoredEbitenImage(5, 5, archetype.ColorToRGBA(colornames.Fuchsia))
 	trackButtonImage := &widget.ButtonImage{
 		Idle:     image.NewNineSliceSimple(track, 0, 5),
@@ -76,62 +195,44 @@ func NewAvailableSessionsMenu(gameData *component.GameData) *AvailableSessionsMe
 		Pressed:  image.NewNineSliceSimple(track, 0, 5),
 		Disabled: image.NewNineSliceSimple(track, 0, 5),
 	}
-	availableSessionsMenu.list = widget.NewList(
-		//Set how wide the list should be
-		widget.ListOpts.ContainerOpts(widget.ContainerOpts.WidgetOpts(
-			widget.WidgetOpts.MinSize(150, 0),
-			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
-				HorizontalPosition: widget.AnchorLayoutPositionCenter,
-				VerticalPosition:   widget.AnchorLayoutPositionCenter,
-				StretchVertical:    true,
-			}),
-		)),
-		//Set the entries in the list
-		widget.ListOpts.Entries(entries),
-		widget.ListOpts.ScrollContainerOpts(
-			//Set the background images/color for the list
-			widget.ScrollContainerOpts.Image(&widget.ScrollContainerImage{
-				Idle:     image.NewNineSliceColor(color.White),
-				Disabled: image.NewNineSliceColor(color.White),
-				Mask:     image.NewNineSliceColor(color.White),
-			}),
-		),
-		widget.ListOpts.SliderOpts(
-			//Set the background images/color for the background of the slider track
-			widget.SliderOpts.Images(&widget.SliderTrackImage{
-				Idle:  image.NewNineSliceColor(colornames.Fuchsia),
-				Hover: image.NewNineSliceColor(colornames.Fuchsia),
-			}, trackButtonImage),
-			widget.SliderOpts.MinHandleSize(5),
-			//Set how wide the track should be
-			widget.SliderOpts.TrackPadding(widget.NewInsetsSimple(2))),
-		//Hide the horizontal slider
-		widget.ListOpts.HideHorizontalSlider(),
-		//Set the font for the list options
-		widget.ListOpts.EntryFontFace(assets.MainMidFont),
-		//Set the colors for the list
-		widget.ListOpts.EntryColor(&widget.ListEntryColor{
-			Selected:                   colornames.White, //Foreground color for the unfocused selected entry
-			Unselected:                 color.Black,      //Foreground color for the unfocused unselected entry
-			SelectedBackground:         assets.BlueColor, //Background color for the unfocused selected entry
-			SelectedFocusedBackground:  assets.BlueColor, //Background color for the focused selected entry
-			FocusedBackground:          assets.BlueColor, //Background color for the focused unselected entry
-			DisabledUnselected:         assets.BlueColor, //Foreground color for the disabled unselected entry
-			DisabledSelected:           assets.BlueColor, //Foreground color for the disabled selected entry
-			DisabledSelectedBackground: assets.BlueColor, //Background color for the disabled selected entry
-		}),
-		//This required function returns the string displayed in the list
-		widget.ListOpts.EntryLabelFunc(func(e interface{}) string {
-			return e.(net.AvailableSession).SessionHostName
-		}),
-		//Padding for each entry
-		widget.ListOpts.EntryTextPadding(widget.NewInsetsSimple(5)),
-		//This handler defines what function to run when a list item is selected.
-		widget.ListOpts.EntrySelectedHandler(func(args *widget.ListEntrySelectedEventArgs) {
-			selectedSession := args.Entry.(net.AvailableSession)
-			availableSessionsMenu.Session = &selectedSession
+	pageSize := func() int {
+		contentHeight := availableSessionsMenu.rows.GetWidget().Rect.Dy()
+		if contentHeight == 0 {
+			return 1000
+		}
+		return int(math.Round(float64(availableSessionsMenu.scroll.ContentRect().Dy()) / float64(contentHeight) * 1000))
+	}
+	availableSessionsMenu.slider = widget.NewSlider(
+		widget.SliderOpts.Direction(widget.DirectionVertical),
+		widget.SliderOpts.MinMax(0, 1000),
+		widget.SliderOpts.PageSizeFunc(pageSize),
+		widget.SliderOpts.Images(&widget.SliderTrackImage{
+			Idle:  image.NewNineSliceColor(colornames.Fuchsia),
+			Hover: image.NewNineSliceColor(colornames.Fuchsia),
+		}, trackButtonImage),
+		widget.SliderOpts.MinHandleSize(5),
+		widget.SliderOpts.TrackPadding(widget.NewInsetsSimple(2)),
+		widget.SliderOpts.ChangedHandler(func(args *widget.SliderChangedEventArgs) {
+			availableSessionsMenu.scroll.ScrollTop = float64(args.Slider.Current) / 1000
 		}),
 	)
+	availableSessionsMenu.scroll.GetWidget().ScrolledEvent.AddHandler(func(args interface{}) {
+		scrolled := args.(*widget.WidgetScrolledEventArgs)
+		step := pageSize() / 3
+		if step < 1 {
+			step = 1
+		}
+		availableSessionsMenu.slider.Current -= int(math.Round(scrolled.Y * float64(step)))
+	})
+
+	availableSessionsMenu.list = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(2),
+			widget.GridLayoutOpts.Stretch([]bool{true, false}, []bool{true}),
+		)),
+	)
+	availableSessionsMenu.list.AddChild(availableSessionsMenu.scroll)
+	availableSessionsMenu.list.AddChild(availableSessionsMenu.slider)
 
 	availableSessionsMenu.listLayoutData = widget.RowLayoutData{
 		Stretch:   false,
@@ -238,6 +339,8 @@ func NewAvailableSessionsMenu(gameData *component.GameData) *AvailableSessionsMe
 	buttonsContainer.AddChild(availableSessionsMenu.joinButton)
 
 	parentContainer.AddChild(availableSessionsLabelContainer)
+	parentContainer.AddChild(controlsContainer)
+	parentContainer.AddChild(sessionsHeaderLabel)
 	parentContainer.AddChild(availableSessionsMenu.list)
 	parentContainer.AddChild(buttonsContainer)
 
@@ -256,19 +359,149 @@ func NewAvailableSessionsMenu(gameData *component.GameData) *AvailableSessionsMe
 	return availableSessionsMenu
 }
 
+// refreshSessions fetches the sessions in the background, Update picks them
+// up from the refreshed channel so the menu state is only touched by the game
+// loop.
 func (s *AvailableSessionsMenu) refreshSessions() {
+	if s.refreshing {
+		return
+	}
+	s.refreshing = true
+	s.lastRefresh = time.Now()
 	go func() {
-		s.sessions = net.GetAvailableSessions()
-		if s.sessions != nil {
-			filtered := lo.Filter(*s.sessions, func(session net.AvailableSession, index int) bool {
-				return session.Size < component.MaxPlayers
-			})
-			s.sessions = &filtered
-		}
-		s.shouldUpdate = s.sessions != nil
+		s.refreshed <- net.GetAvailableSessions()
 	}()
 }
 
+func (s *AvailableSessionsMenu) receiveSessions() {
+	select {
+	case sessions := <-s.refreshed:
+		s.refreshing = false
+		if sessions != nil {
+			s.sessions = sessions
+			s.shouldUpdate = true
+		}
+	default:
+	}
+}
+
+// joinable tells if the session can be selected, and why not otherwise.
+func joinable(session net.AvailableSession) (bool, string) {
+	switch {
+	case !session.Compatible():
+		return false, "incompatible"
+	case session.Locked:
+		return false, "locked"
+	case session.Size >= component.MaxPlayers:
+		return false, "full"
+	}
+	return true, ""
+}
+
+func sessionLabel(session net.AvailableSession) string {
+	orDash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	level := "-"
+	if session.Compatible() {
+		level = fmt.Sprintf("%d", session.Level+1)
+	}
+	ping := "-"
+	if session.Ping > 0 {
+		ping = fmt.Sprintf("%dms", session.Ping.Milliseconds())
+	}
+	label := fmt.Sprintf("%-12.12s  %d/%d      %-5s  %-8.8s  %-8.8s  %s",
+		session.SessionHostName,
+		session.Size,
+		component.MaxPlayers,
+		level,
+		orDash(session.Mode),
+		orDash(session.Phase),
+		ping,
+	)
+	if ok, reason := joinable(session); !ok {
+		label += " (" + reason + ")"
+	}
+	return label
+}
+
+// visibleSessions filters the sessions with the search text and sorts them,
+// sessions that can not be joined go last.
+func (s *AvailableSessionsMenu) visibleSessions() []net.AvailableSession {
+	visible := lo.Filter(*s.sessions, func(session net.AvailableSession, index int) bool {
+		return s.search == "" || strings.Contains(strings.ToLower(session.SessionHostName), s.search)
+	})
+	sort.SliceStable(visible, func(i, j int) bool {
+		iok, _ := joinable(visible[i])
+		jok, _ := joinable(visible[j])
+		if iok != jok {
+			return iok
+		}
+		if s.sortBy == SortByPing && visible[i].Ping != visible[j].Ping {
+			return visible[i].FasterThan(visible[j])
+		}
+		if s.sortBy == SortByPlayers && visible[i].Size != visible[j].Size {
+			return visible[i].Size > visible[j].Size
+		}
+		return visible[i].SessionHostName < visible[j].SessionHostName
+	})
+	return visible
+}
+
+func (s *AvailableSessionsMenu) updateRows() {
+	idle := image.NewNineSliceColor(color.White)
+	selected := image.NewNineSliceColor(assets.BlueColor)
+	s.rows.RemoveChildren()
+	s.rowButtons = map[string]*widget.Button{}
+	var stillListed *net.AvailableSession
+	for _, session := range s.visibleSessions() {
+		session := session
+		ok, _ := joinable(session)
+		row := widget.NewButton(
+			widget.ButtonOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.RowLayoutData{
+				Stretch: true,
+			})),
+			widget.ButtonOpts.Image(&widget.ButtonImage{
+				Idle:     idle,
+				Hover:    selected,
+				Pressed:  selected,
+				Disabled: idle,
+			}),
+			widget.ButtonOpts.TextSimpleLeft(sessionLabel(session), assets.MainFont, &widget.ButtonTextColor{
+				Idle:     color.Black,
+				Disabled: colornames.Grey,
+			}, widget.NewInsetsSimple(5)),
+			widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+				s.selectSession(&session)
+			}),
+		)
+		row.GetWidget().Disabled = !ok
+		s.rows.AddChild(row)
+		s.rowButtons[session.ID] = row
+		if ok && s.Session != nil && s.Session.ID == session.ID {
+			stillListed = &session
+		}
+	}
+	// keep the selection only while the session can still be joined
+	s.selectSession(stillListed)
+}
+
+func (s *AvailableSessionsMenu) selectSession(session *net.AvailableSession) {
+	s.Session = session
+	for id, row := range s.rowButtons {
+		if session != nil && id == session.ID {
+			row.Image.Idle = image.NewNineSliceColor(assets.BlueColor)
+			row.TextColor.Idle = colornames.White
+		} else {
+			row.Image.Idle = image.NewNineSliceColor(color.White)
+			row.TextColor.Idle = color.Black
+		}
+	}
+}
+
 func (s *AvailableSessionsMenu) Draw(screen *ebiten.Image) {
 	s.Ui.Draw(screen)
 }
@@ -278,13 +511,13 @@ func (s *AvailableSessionsMenu) Container() *widget.Container {
 }
 
 func (s *AvailableSessionsMenu) Update() {
+	s.receiveSessions()
 	if s.shouldUpdate {
-		entries := make([]any, 0, len(*s.sessions))
-		for _, session := range *s.sessions {
-			entries = append(entries, session)
-		}
-		s.list.SetEntries(entries)
 		s.shouldUpdate = false
+		s.updateRows()
+	}
+	if time.Since(s.lastRefresh) >= sessionsRefreshInterval {
+		s.refreshSessions()
 	}
 	listWidget := s.list.GetWidget()
 	listWidget.MinWidth = (s.gameData.Settings.ScreenHeight / 2) + 64
@@ -297,8 +530,10 @@ func (s *AvailableSessionsMenu) Update() {
 	cancelButtonRect := s.cancelButton.GetWidget().Rect
 	joinButtonRect := s.joinButton.GetWidget().Rect
 	refreshButtonRect := s.refreshButton.GetWidget().Rect
+	sortButtonRect := s.sortButton.GetWidget().Rect
 	mx, my := ebiten.CursorPosition()
 	if (cancelButtonRect.Min.X <= mx && mx <= cancelButtonRect.Max.X && cancelButtonRect.Min.Y <= my && my <= cancelButtonRect.Max.Y) ||
+		(sortButtonRect.Min.X <= mx && mx <= sortButtonRect.Max.X && sortButtonRect.Min.Y <= my && my <= sortButtonRect.Max.Y) ||
 		(joinButtonRect.Min.X <= mx && mx <= joinButtonRect.Max.X && joinButtonRect.Min.Y <= my && my <= joinButtonRect.Max.Y) ||
 		(refreshButtonRect.Min.X <= mx && mx <= refreshButtonRect.Max.X && refreshButtonRect.Min.Y <= my && my <= refreshButtonRect.Max.Y) {
 		archetype.UpdateCursorImage(true)
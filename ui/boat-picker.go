@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"golang.org/x/image/colornames"
+)
+
+// BoatPicker cycles through the hulls and the colors, the choice is stored
+// in the profile and sent to the other players when a round starts.
+type BoatPicker struct {
+	container  *widget.Container
+	hullButton *widget.Button
+	tintButton *widget.Button
+}
+
+func NewBoatPicker() *BoatPicker {
+	picker := &BoatPicker{
+		container: widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewGridLayout(
+				widget.GridLayoutOpts.Columns(2),
+				widget.GridLayoutOpts.Spacing(10, 3),
+			)),
+		),
+	}
+	picker.hullButton = newPickerButton(component.FindHull(component.CurrentProfile.Hull).Label, func() {
+		hulls := component.Hulls
+		for i, hull := range hulls {
+			if hull.Name == component.FindHull(component.CurrentProfile.Hull).Name {
+				component.CurrentProfile.Hull = hulls[(i+1)%len(hulls)].Name
+				break
+			}
+		}
+		component.CurrentProfile.Save()
+		picker.hullButton.Text().Label = component.FindHull(component.CurrentProfile.Hull).Label
+	})
+	picker.tintButton = newPickerButton(component.FindBoatTint(component.CurrentProfile.Tint).Label, func() {
+		tints := component.BoatTints
+		for i, tint := range tints {
+			if tint.Name == component.FindBoatTint(component.CurrentProfile.Tint).Name {
+				component.CurrentProfile.Tint = tints[(i+1)%len(tints)].Name
+				break
+			}
+		}
+		component.CurrentProfile.Save()
+		picker.tintButton.Text().Label = component.FindBoatTint(component.CurrentProfile.Tint).Label
+	})
+	picker.container.AddChild(picker.hullButton)
+	picker.container.AddChild(picker.tintButton)
+	return picker
+}
+
+func newPickerButton(label string, clicked func()) *widget.Button {
+	return widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 40, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(label, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    5,
+			Bottom: 5,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			clicked()
+		}),
+	)
+}
+
+func (p *BoatPicker) Container() *widget.Container {
+	return p.container
+}
+
+// Hovered tells if the cursor is over one of the picker buttons.
+func (p *BoatPicker) Hovered(mx int, my int) bool {
+	for _, button := range []*widget.Button{p.hullButton, p.tintButton} {
+		rect := button.GetWidget().Rect
+		if rect.Min.X <= mx && mx <= rect.Max.X && rect.Min.Y <= my && my <= rect.Max.Y {
+			return true
+		}
+	}
+	return false
+}
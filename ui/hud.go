@@ -5,6 +5,7 @@ import (
 	"amaru/assets"
 	"amaru/component"
 	"fmt"
+	"strings"
 
 	"github.com/ebitenui/ebitenui"
 	"github.com/ebitenui/ebitenui/image"
@@ -38,6 +39,11 @@ type HudUi struct {
 	World              *donburi.World
 	remainingTimeLabel *widget.Label
 	playerPointsLabel  *widget.Label
+	powerUpsLabel      *widget.Label
+	coopContainer      *widget.Container
+	coopBar            *widget.ProgressBar
+	coopLabel          *widget.Label
+	coopShown          bool
 }
 
 func NewHudUI() *HudUi {
@@ -69,6 +75,26 @@ func NewHudUI() *HudUi {
 	playerPointsContainer.AddChild(hudUi.playerPointsLabel)
 	container.AddChild(playerPointsContainer)
 
+	// seconds left of the active power-ups, under the points
+	powerUpsContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout(widget.AnchorLayoutOpts.Padding(widget.Insets{
+			Left: 10,
+			Top:  56,
+		}))),
+	)
+	hudUi.powerUpsLabel = widget.NewLabel(
+		widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+			Disabled: colornames.White,
+			Idle:     colornames.White,
+		}),
+	)
+	hudUi.powerUpsLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionStart,
+		VerticalPosition:   widget.AnchorLayoutPositionStart,
+	}
+	powerUpsContainer.AddChild(hudUi.powerUpsLabel)
+	container.AddChild(powerUpsContainer)
+
 	remainingContainer := widget.NewContainer(
 		widget.ContainerOpts.Layout(widget.NewAnchorLayout(widget.AnchorLayoutOpts.Padding(widget.Insets{
 			Top: 5,
@@ -87,6 +113,46 @@ func NewHudUI() *HudUi {
 	remainingContainer.AddChild(hudUi.remainingTimeLabel)
 	container.AddChild(remainingContainer)
 
+	// shared goal of co-op rounds, added to the HUD once the mode is known
+	hudUi.coopContainer = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout(widget.AnchorLayoutOpts.Padding(widget.Insets{
+			Top: 48,
+		}))),
+	)
+	coopProgressContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(1),
+			widget.GridLayoutOpts.Spacing(0, 2),
+		)),
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			HorizontalPosition: widget.AnchorLayoutPositionCenter,
+			VerticalPosition:   widget.AnchorLayoutPositionStart,
+		})),
+	)
+	hudUi.coopBar = widget.NewProgressBar(
+		widget.ProgressBarOpts.WidgetOpts(widget.WidgetOpts.MinSize(200, 12)),
+		widget.ProgressBarOpts.Images(
+			&widget.ProgressBarImage{
+				Idle: image.NewNineSliceColor(colornames.White),
+			},
+			&widget.ProgressBarImage{
+				Idle: image.NewNineSliceColor(assets.GreenColor),
+			},
+		),
+		widget.ProgressBarOpts.TrackPadding(widget.NewInsetsSimple(2)),
+		widget.ProgressBarOpts.Values(0, 100, 0),
+	)
+	hudUi.coopLabel = widget.NewLabel(
+		widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+			Disabled: colornames.White,
+			Idle:     colornames.White,
+		}),
+		widget.LabelOpts.TextOpts(widget.TextOpts.Position(widget.TextPositionCenter, widget.TextPositionCenter)),
+	)
+	coopProgressContainer.AddChild(hudUi.coopBar)
+	coopProgressContainer.AddChild(hudUi.coopLabel)
+	hudUi.coopContainer.AddChild(coopProgressContainer)
+
 	compositeContainer := widget.NewContainer(
 		// the container will use an anchor layout to layout its single child widget
 		widget.ContainerOpts.Layout(widget.NewAnchorLayout(
@@ -211,6 +277,30 @@ func (s *HudUi) Container() *widget.Container {
 	return s.container
 }
 
+func (s *HudUi) updateCoop() {
+	gameData := s.Game.Session.RemoteClient.GameData
+	if !gameData.CoopMode() {
+		return
+	}
+	if !s.coopShown {
+		s.container.AddChild(s.coopContainer)
+		s.coopShown = true
+	}
+	gameData.UpdateCoop()
+	s.coopBar.SetCurrent(gameData.Coop.Percent())
+	s.coopLabel.Label = fmt.Sprintf("Waste %d/%d  Animals %d/%d", gameData.Coop.Waste, gameData.Coop.WasteGoal(), gameData.Coop.Animals, gameData.Coop.AnimalsSize)
+}
+
+func (s *HudUi) updatePowerUps(player *component.PlayerData) {
+	timers := []string{}
+	for _, kind := range component.PowerUpKinds {
+		if frames := player.PowerUps[kind]; frames > 0 {
+			timers = append(timers, fmt.Sprintf("%s %ds", component.PowerUpLabels[kind], (frames+59)/60))
+		}
+	}
+	s.powerUpsLabel.Label = strings.Join(timers, "  ")
+}
+
 func (s *HudUi) Update() {
 	if s.World != nil {
 		if s.Game == nil {
@@ -223,6 +313,7 @@ func (s *HudUi) Update() {
 		}
 
 		s.remainingTimeLabel.Label = fmt.Sprintf("%02d", s.Game.Session.RemoteClient.GameData.Counter)
+		s.updateCoop()
 
 		player, _ := archetype.MustFindLocalPlayer(*s.World)
 		if player != nil {
@@ -230,6 +321,7 @@ func (s *HudUi) Update() {
 			if sessionParticipant != nil {
 				s.playerPointsLabel.Label = fmt.Sprintf("%d", sessionParticipant.Score)
 			}
+			s.updatePowerUps(player)
 		}
 
 		game := component.MustFindGame(*s.World)
@@ -237,6 +329,10 @@ func (s *HudUi) Update() {
 			if s.Game.Session.Type == component.SessionTypeHost && s.Game.Session.RemoteClient.GameData.Counter <= 0 {
 				game.GameOver = true
 			}
+			// a co-op round ends as soon as the group reaches the goal
+			if s.Game.Session.Type == component.SessionTypeHost && s.Game.Session.RemoteClient.GameData.CoopMode() && s.Game.Session.RemoteClient.GameData.Coop.Cleared() {
+				game.GameOver = true
+			}
 			s.ui.Container.GetWidget().LayoutData = widget.RowLayoutData{
 				MaxWidth:  int(game.LeftOffset),
 				MaxHeight: game.Settings.ScreenHeight,
@@ -0,0 +1,293 @@
+package ui
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"amaru/net"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/ebitenui/ebitenui"
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	newMatchLabel   = "New Match"
+	lobbyLabel      = "Lobby"
+	waitingForHost  = "Waiting for the host"
+	podiumStepWidth = 110
+)
+
+type podiumPlace struct {
+	index      int
+	height     int
+	color      color.Color
+	nameLabel  *widget.Label
+	scoreLabel *widget.Label
+}
+
+type PodiumUI struct {
+	container      *widget.Container
+	Ui             *ebitenui.UI
+	Game           *component.GameData
+	titleLabel     *widget.Label
+	othersLabel    *widget.Label
+	places         []*podiumPlace
+	newMatchButton *widget.Button
+	lobbyButton    *widget.Button
+	NewMatch       bool
+	Lobby          bool
+}
+
+func NewPodiumUI(gameData *component.GameData) *PodiumUI {
+	podiumUI := &PodiumUI{
+		container: widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewAnchorLayout()),
+		),
+		Game: gameData,
+		// second, first and third from left to right
+		places: []*podiumPlace{
+			{index: 1, height: 60, color: colornames.Silver},
+			{index: 0, height: 90, color: colornames.Gold},
+			{index: 2, height: 40, color: colornames.Peru},
+		},
+	}
+
+	parentContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(1),
+			widget.GridLayoutOpts.Padding(widget.NewInsetsSimple(20)),
+			widget.GridLayoutOpts.Spacing(10, 10),
+		)),
+	)
+
+	podiumUI.titleLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainMidFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+
+	titleContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout(
+		widget.AnchorLayoutOpts.Padding(widget.NewInsetsSimple(0)),
+	)))
+	titleContainer.AddChild(podiumUI.titleLabel)
+	podiumUI.titleLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	podiumContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(len(podiumUI.places)),
+		widget.GridLayoutOpts.Spacing(0, 0),
+		widget.GridLayoutOpts.Stretch([]bool{false, false, false}, []bool{true}),
+	)))
+	for _, place := range podiumUI.places {
+		// the steps stand on the same line whatever their height
+		stepContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+		step := widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewRowLayout(
+				widget.RowLayoutOpts.Direction(widget.DirectionVertical),
+				widget.RowLayoutOpts.Spacing(4),
+			)),
+			widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionEnd,
+			})),
+		)
+		place.nameLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+			Disabled: assets.BlueColor,
+			Idle:     assets.BlueColor,
+		}))
+		place.nameLabel.GetWidget().LayoutData = widget.RowLayoutData{Position: widget.RowLayoutPositionCenter}
+		place.scoreLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+			Disabled: assets.BlueColor,
+			Idle:     assets.BlueColor,
+		}))
+		place.scoreLabel.GetWidget().LayoutData = widget.RowLayoutData{Position: widget.RowLayoutPositionCenter}
+		block := widget.NewGraphic(widget.GraphicOpts.Image(archetype.NewColoredEbitenImage(podiumStepWidth, place.height, archetype.ColorToRGBA(place.color))))
+		step.AddChild(place.nameLabel)
+		step.AddChild(place.scoreLabel)
+		step.AddChild(block)
+		stepContainer.AddChild(step)
+		podiumContainer.AddChild(stepContainer)
+	}
+
+	podiumUI.othersLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+	othersContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+	othersContainer.AddChild(podiumUI.othersLabel)
+	podiumUI.othersLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	buttonsContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(2),
+		widget.GridLayoutOpts.Spacing(10, 3),
+		widget.GridLayoutOpts.Stretch([]bool{true, true}, []bool{true}),
+	)))
+
+	// only the host starts the next match, peers follow it
+	if gameData.Session.Type == component.SessionTypeHost {
+		podiumUI.newMatchButton = widget.NewButton(
+			widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+			widget.ButtonOpts.Text(newMatchLabel, assets.MainFont, &widget.ButtonTextColor{
+				Idle:     assets.BlueColor,
+				Disabled: assets.BlueColor,
+			}),
+			widget.ButtonOpts.TextPadding(widget.Insets{
+				Top:    10,
+				Bottom: 10,
+				Left:   10,
+				Right:  10,
+			}),
+			widget.ButtonOpts.WidgetOpts(
+
+				widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+					HorizontalPosition: widget.AnchorLayoutPositionCenter,
+					VerticalPosition:   widget.AnchorLayoutPositionCenter,
+				}),
+				widget.WidgetOpts.CursorHovered("buttonHover"),
+				widget.WidgetOpts.CursorPressed("buttonPressed"),
+			),
+			widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+				archetype.PlayButtonClickAudio()
+				podiumUI.NewMatch = true
+			}),
+		)
+		buttonsContainer.AddChild(podiumUI.newMatchButton)
+	} else {
+		waitingContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+		waitingLabel := widget.NewLabel(widget.LabelOpts.Text(waitingForHost, assets.MainFont, &widget.LabelColor{
+			Disabled: assets.BlueColor,
+			Idle:     assets.BlueColor,
+		}))
+		waitingLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+			HorizontalPosition: widget.AnchorLayoutPositionCenter,
+			VerticalPosition:   widget.AnchorLayoutPositionCenter,
+		}
+		waitingContainer.AddChild(waitingLabel)
+		buttonsContainer.AddChild(waitingContainer)
+	}
+
+	podiumUI.lobbyButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(lobbyLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			podiumUI.Lobby = true
+		}),
+	)
+	buttonsContainer.AddChild(podiumUI.lobbyButton)
+
+	parentContainer.AddChild(titleContainer)
+	parentContainer.AddChild(podiumContainer)
+	parentContainer.AddChild(othersContainer)
+	parentContainer.AddChild(buttonsContainer)
+
+	podiumUI.container.AddChild(parentContainer)
+	parentContainer.GetWidget().LayoutData = widget.AnchorLayoutData{
+		VerticalPosition:   widget.AnchorLayoutPositionCenter,
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	podiumUI.Ui = &ebitenui.UI{
+		Container: podiumUI.container,
+	}
+	podiumUI.refreshStandings()
+
+	return podiumUI
+}
+
+// refreshStandings fills the podium from the match totals, peers receive them
+// from the host after the screen is created.
+func (s *PodiumUI) refreshStandings() {
+	gameData := s.Game.Session.RemoteClient.GameData
+	if gameData == nil {
+		return
+	}
+	standings := net.RankStandings(gameData.SessionParticipants)
+	winners := net.Winners(standings)
+	switch len(winners) {
+	case 0:
+		s.titleLabel.Label = "No match winner"
+	case 1:
+		s.titleLabel.Label = fmt.Sprintf("Match winner: %s", winners[0])
+	default:
+		s.titleLabel.Label = fmt.Sprintf("Tie: %s", strings.Join(winners, ", "))
+	}
+	if gameData.CoopMode() {
+		s.titleLabel.Label = fmt.Sprintf("Goal reached in %d of %d rounds", gameData.CoopCleared, gameData.Round)
+	}
+	if gameData.TeamMode() {
+		title, totals := teamResults(net.TeamScores(gameData.SessionParticipants, func(participant *net.SessionParticipant) int {
+			return participant.Totals.Score
+		}))
+		s.titleLabel.Label = fmt.Sprintf("%s (%s)", title, totals)
+	}
+	for _, place := range s.places {
+		if place.index >= len(standings) {
+			place.nameLabel.Label = ""
+			place.scoreLabel.Label = ""
+			continue
+		}
+		result := standings[place.index]
+		place.nameLabel.Label = fmt.Sprintf("#%d %s", result.Rank, net.ParticipantName(result.Participant))
+		place.scoreLabel.Label = fmt.Sprintf("%d points", result.Score)
+	}
+	others := []string{}
+	for i, result := range standings {
+		if i < len(s.places) {
+			continue
+		}
+		others = append(others, fmt.Sprintf("#%d %s (%d)", result.Rank, net.ParticipantName(result.Participant), result.Score))
+	}
+	s.othersLabel.Label = strings.Join(others, "  ")
+}
+
+func (s *PodiumUI) Draw(screen *ebiten.Image) {
+	s.Ui.Draw(screen)
+}
+
+func (s *PodiumUI) Container() *widget.Container {
+	return s.container
+}
+
+func (s *PodiumUI) Update() {
+	s.Ui.Update()
+	s.refreshStandings()
+	hovered := false
+	mx, my := ebiten.CursorPosition()
+	for _, button := range []*widget.Button{s.newMatchButton, s.lobbyButton} {
+		if button == nil {
+			continue
+		}
+		rect := button.GetWidget().Rect
+		if rect.Min.X <= mx && mx <= rect.Max.X && rect.Min.Y <= my && my <= rect.Max.Y {
+			hovered = true
+		}
+	}
+	archetype.UpdateCursorImage(hovered)
+}
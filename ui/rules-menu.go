@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/net"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/ebitenui/ebitenui"
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	rulesTitle   = "Rules"
+	publicLabel  = "Public session"
+	privateLabel = "Private session"
+)
+
+var modeLabels = map[string]string{
+	net.SessionModeClassic: "Free for all",
+	net.SessionModeTeams:   "Teams 2v2",
+	net.SessionModeCoop:    "Co-op",
+}
+
+// modes are cycled by the mode button in this order
+var modes = []string{net.SessionModeClassic, net.SessionModeTeams, net.SessionModeCoop}
+
+type RulesMenuUI struct {
+	container    *widget.Container
+	Ui           *ebitenui.UI
+	ruleButtons  []*widget.Button
+	cancelButton *widget.Button
+	modeButton   *widget.Button
+	lockButton   *widget.Button
+	Mode         string
+	Private      bool
+	Selected     *net.RuleSet
+	Cancel       bool
+}
+
+// NewRulesMenuUI lists the rule presets a host can pick, the current one is
+// highlighted. The mode button switches between free for all, teams and co-op,
+// a private session is listed as locked and can only be joined with its id.
+func NewRulesMenuUI(current string, mode string, private bool) *RulesMenuUI {
+	if _, ok := modeLabels[mode]; !ok {
+		mode = net.SessionModeClassic
+	}
+	rulesMenu := &RulesMenuUI{
+		container: widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewAnchorLayout()),
+		),
+		Mode:    mode,
+		Private: private,
+	}
+
+	parentContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(1),
+			widget.GridLayoutOpts.Padding(widget.NewInsetsSimple(20)),
+			widget.GridLayoutOpts.Spacing(15, 5),
+		)),
+	)
+
+	rulesLabel := widget.NewLabel(widget.LabelOpts.Text(rulesTitle, assets.MainMidFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+
+	rulesLabelContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout(
+		widget.AnchorLayoutOpts.Padding(widget.NewInsetsSimple(0)),
+	)))
+
+	rulesLabelContainer.AddChild(rulesLabel)
+	rulesLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	buttonsContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(2),
+		widget.GridLayoutOpts.Spacing(10, 5),
+		widget.GridLayoutOpts.Stretch([]bool{true, false}, []bool{true}),
+	)))
+
+	for next := range net.RuleSets {
+		rules := net.RuleSets[next]
+		textColor := &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}
+		if rules.Name == current {
+			textColor = &widget.ButtonTextColor{
+				Idle:     assets.GreenColor,
+				Disabled: assets.GreenColor,
+			}
+		}
+		ruleButton := widget.NewButton(
+			widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(120, 40, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+			widget.ButtonOpts.Text(rules.Label, assets.MainFont, textColor),
+			widget.ButtonOpts.TextPadding(widget.Insets{
+				Top:    5,
+				Bottom: 5,
+				Left:   10,
+				Right:  10,
+			}),
+			widget.ButtonOpts.WidgetOpts(
+
+				widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+					HorizontalPosition: widget.AnchorLayoutPositionCenter,
+					VerticalPosition:   widget.AnchorLayoutPositionCenter,
+				}),
+				widget.WidgetOpts.CursorHovered("buttonHover"),
+				widget.WidgetOpts.CursorPressed("buttonPressed"),
+			),
+			widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+				archetype.PlayButtonClickAudio()
+				rules.Mode = rulesMenu.Mode
+				rulesMenu.Selected = &rules
+			}),
+		)
+		buttonsContainer.AddChild(ruleButton)
+		rulesMenu.ruleButtons = append(rulesMenu.ruleButtons, ruleButton)
+
+		descriptionLabel := widget.NewLabel(
+			widget.LabelOpts.Text(rules.Description, assets.MainFont, &widget.LabelColor{
+				Disabled: assets.BlueColor,
+				Idle:     assets.BlueColor,
+			}),
+			widget.LabelOpts.TextOpts(widget.TextOpts.Position(widget.TextPositionStart, widget.TextPositionCenter)),
+		)
+		buttonsContainer.AddChild(descriptionLabel)
+	}
+
+	modeContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+	rulesMenu.modeButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 40, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(modeLabels[mode], assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    5,
+			Bottom: 5,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			for i, mode := range modes {
+				if mode == rulesMenu.Mode {
+					rulesMenu.Mode = modes[(i+1)%len(modes)]
+					break
+				}
+			}
+			rulesMenu.modeButton.Text().Label = modeLabels[rulesMenu.Mode]
+		}),
+	)
+	modeContainer.AddChild(rulesMenu.modeButton)
+
+	lockContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+	rulesMenu.lockButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 40, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(lockLabel(private), assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    5,
+			Bottom: 5,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			rulesMenu.Private = !rulesMenu.Private
+			rulesMenu.lockButton.Text().Label = lockLabel(rulesMenu.Private)
+		}),
+	)
+	lockContainer.AddChild(rulesMenu.lockButton)
+
+	cancelContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(1),
+		widget.GridLayoutOpts.Spacing(10, 3),
+		widget.GridLayoutOpts.Stretch([]bool{true}, []bool{true}),
+	)))
+
+	rulesMenu.cancelButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(cancelLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			rulesMenu.Cancel = true
+		}),
+	)
+	cancelContainer.AddChild(rulesMenu.cancelButton)
+
+	parentContainer.AddChild(rulesLabelContainer)
+	parentContainer.AddChild(modeContainer)
+	parentContainer.AddChild(lockContainer)
+	parentContainer.AddChild(buttonsContainer)
+	parentContainer.AddChild(cancelContainer)
+
+	rulesMenu.container.AddChild(parentContainer)
+	parentContainer.GetWidget().LayoutData = widget.AnchorLayoutData{
+		VerticalPosition:   widget.AnchorLayoutPositionCenter,
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	rulesMenu.Ui = &ebitenui.UI{
+		Container: rulesMenu.container,
+	}
+	return rulesMenu
+}
+
+func lockLabel(private bool) string {
+	if private {
+		return privateLabel
+	}
+	return publicLabel
+}
+
+func (s *RulesMenuUI) Draw(screen *ebiten.Image) {
+	s.Ui.Draw(screen)
+}
+
+func (s *RulesMenuUI) Container() *widget.Container {
+	return s.container
+}
+
+func (s *RulesMenuUI) Update() {
+	s.Ui.Update()
+	hovered := false
+	mx, my := ebiten.CursorPosition()
+	for _, button := range append([]*widget.Button{s.cancelButton, s.modeButton, s.lockButton}, s.ruleButtons...) {
+		rect := button.GetWidget().Rect
+		if rect.Min.X <= mx && mx <= rect.Max.X && rect.Min.Y <= my && my <= rect.Max.Y {
+			hovered = true
+		}
+	}
+	archetype.UpdateCursorImage(hovered)
+}
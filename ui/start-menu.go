@@ -18,6 +18,8 @@ const (
 	cancelLabel  = "Cancel"
 	refreshLabel = "Refresh"
 	aboutLabel   = "About"
+	quickLabel   = "Quick Play"
+	statsLabel   = "Stats"
 )
 
 type StartMenuOption int
@@ -27,6 +29,8 @@ const (
 	Host
 	Join
 	About
+	QuickPlay
+	Stats
 )
 
 type StartMenu struct {
@@ -35,7 +39,9 @@ type StartMenu struct {
 	Ui             *ebitenui.UI
 	hostButton     *widget.Button
 	joinButton     *widget.Button
+	quickButton    *widget.Button
 	aboutButton    *widget.Button
+	statsButton    *widget.Button
 }
 
 func NewStartMenu() *StartMenu {
@@ -67,6 +73,40 @@ func NewStartMenu() *StartMenu {
 		HorizontalPosition: widget.AnchorLayoutPositionCenter,
 	}
 
+	quickContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(1),
+		widget.GridLayoutOpts.Spacing(10, 3),
+		widget.GridLayoutOpts.Stretch([]bool{true}, []bool{true}),
+	)))
+
+	startMenu.quickButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(quickLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			startMenu.SelectedOption = QuickPlay
+		}),
+	)
+	quickContainer.AddChild(startMenu.quickButton)
+
 	buttonsContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
 		widget.GridLayoutOpts.Columns(2),
 		widget.GridLayoutOpts.Spacing(10, 3),
@@ -130,11 +170,39 @@ func NewStartMenu() *StartMenu {
 	buttonsContainer.AddChild(startMenu.joinButton)
 
 	aboutContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
-		widget.GridLayoutOpts.Columns(1),
+		widget.GridLayoutOpts.Columns(2),
 		widget.GridLayoutOpts.Spacing(10, 3),
-		widget.GridLayoutOpts.Stretch([]bool{true}, []bool{true}),
+		widget.GridLayoutOpts.Stretch([]bool{true, true}, []bool{true}),
 	)))
 
+	startMenu.statsButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(statsLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			startMenu.SelectedOption = Stats
+		}),
+	)
+	aboutContainer.AddChild(startMenu.statsButton)
+
 	startMenu.aboutButton = widget.NewButton(
 		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
 		widget.ButtonOpts.Text(aboutLabel, assets.MainFont, &widget.ButtonTextColor{
@@ -164,6 +232,7 @@ func NewStartMenu() *StartMenu {
 	aboutContainer.AddChild(startMenu.aboutButton)
 
 	parentContainer.AddChild(welcomeLabelContainer)
+	parentContainer.AddChild(quickContainer)
 	parentContainer.AddChild(buttonsContainer)
 	parentContainer.AddChild(aboutContainer)
 
@@ -192,10 +261,14 @@ func (s *StartMenu) Update() {
 	hostButtonRect := s.hostButton.GetWidget().Rect
 	joinButtonRect := s.joinButton.GetWidget().Rect
 	aboutButtonRect := s.aboutButton.GetWidget().Rect
+	quickButtonRect := s.quickButton.GetWidget().Rect
+	statsButtonRect := s.statsButton.GetWidget().Rect
 	mx, my := ebiten.CursorPosition()
-	if (hostButtonRect.Min.X <= mx && mx <= hostButtonRect.Max.X && hostButtonRect.Min.Y <= my && my <= hostButtonRect.Max.Y) ||
+	if (quickButtonRect.Min.X <= mx && mx <= quickButtonRect.Max.X && quickButtonRect.Min.Y <= my && my <= quickButtonRect.Max.Y) ||
+		(hostButtonRect.Min.X <= mx && mx <= hostButtonRect.Max.X && hostButtonRect.Min.Y <= my && my <= hostButtonRect.Max.Y) ||
 		(joinButtonRect.Min.X <= mx && mx <= joinButtonRect.Max.X && joinButtonRect.Min.Y <= my && my <= joinButtonRect.Max.Y) ||
-		(aboutButtonRect.Min.X <= mx && mx <= aboutButtonRect.Max.X && aboutButtonRect.Min.Y <= my && my <= aboutButtonRect.Max.Y) {
+		(aboutButtonRect.Min.X <= mx && mx <= aboutButtonRect.Max.X && aboutButtonRect.Min.Y <= my && my <= aboutButtonRect.Max.Y) ||
+		(statsButtonRect.Min.X <= mx && mx <= statsButtonRect.Max.X && statsButtonRect.Min.Y <= my && my <= statsButtonRect.Max.Y) {
 		archetype.UpdateCursorImage(true)
 	} else {
 		archetype.UpdateCursorImage(false)
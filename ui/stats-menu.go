@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"amaru/archetype"
+	"amaru/assets"
+	"amaru/component"
+	"fmt"
+	"strings"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/ebitenui/ebitenui"
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	statsTitle     = "Stats"
+	noMatchesLabel = "No matches played yet"
+)
+
+type StatsMenuUI struct {
+	container  *widget.Container
+	Ui         *ebitenui.UI
+	backButton *widget.Button
+	Back       bool
+}
+
+func NewStatsMenuUI(stats *component.Stats) *StatsMenuUI {
+	statsMenu := &StatsMenuUI{
+		container: widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewAnchorLayout()),
+		),
+	}
+
+	parentContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(1),
+			widget.GridLayoutOpts.Padding(widget.NewInsetsSimple(20)),
+			widget.GridLayoutOpts.Spacing(10, 5),
+		)),
+	)
+
+	statsLabel := widget.NewLabel(widget.LabelOpts.Text(statsTitle, assets.MainMidFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+
+	statsLabelContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout(
+		widget.AnchorLayoutOpts.Padding(widget.NewInsetsSimple(0)),
+	)))
+
+	statsLabelContainer.AddChild(statsLabel)
+	statsLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	totalsContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(2),
+		widget.GridLayoutOpts.Spacing(20, 3),
+	)))
+	totals := [][2]string{
+		{"Matches", fmt.Sprintf("%d", stats.Matches)},
+		{"Wins", fmt.Sprintf("%d", stats.Wins)},
+		{"Best score", fmt.Sprintf("%d", stats.BestScore)},
+		{"Waste collected", fmt.Sprintf("%d", stats.TotalWaste)},
+		{"Animals rescued", fmt.Sprintf("%d", stats.TotalAnimals)},
+	}
+	for _, total := range totals {
+		for _, value := range total {
+			totalsContainer.AddChild(widget.NewLabel(widget.LabelOpts.Text(value, assets.MainFont, &widget.LabelColor{
+				Disabled: assets.BlueColor,
+				Idle:     assets.BlueColor,
+			})))
+		}
+	}
+
+	history := newTextArea(historyText(stats.History))
+	history.GetWidget().MinWidth = 420
+	history.GetWidget().MinHeight = 140
+
+	backContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(1),
+		widget.GridLayoutOpts.Spacing(10, 3),
+		widget.GridLayoutOpts.Stretch([]bool{true}, []bool{true}),
+	)))
+
+	statsMenu.backButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(200, 50, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(backLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    10,
+			Bottom: 10,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+				VerticalPosition:   widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			statsMenu.Back = true
+			archetype.PlayButtonClickAudio()
+		}),
+	)
+	backContainer.AddChild(statsMenu.backButton)
+
+	parentContainer.AddChild(statsLabelContainer)
+	parentContainer.AddChild(totalsContainer)
+	parentContainer.AddChild(history)
+	parentContainer.AddChild(backContainer)
+
+	statsMenu.container.AddChild(parentContainer)
+	parentContainer.GetWidget().LayoutData = widget.AnchorLayoutData{
+		VerticalPosition:   widget.AnchorLayoutPositionCenter,
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	statsMenu.Ui = &ebitenui.UI{
+		Container: statsMenu.container,
+	}
+	return statsMenu
+}
+
+// historyText lists the matches oldest first, the text area scrolls to the end
+// so the last match is the one in view.
+func historyText(history []component.MatchRecord) string {
+	if len(history) == 0 {
+		return noMatchesLabel
+	}
+	lines := make([]string, 0, len(history)*3)
+	for i := len(history) - 1; i >= 0; i-- {
+		match := history[i]
+		lines = append(lines,
+			fmt.Sprintf("[color=27BDF5]%s[/color] #%d of %d, level %d, %s", match.Date.Format("Jan 02 15:04"), match.Rank, match.Players, match.Level+1, match.Duration),
+			fmt.Sprintf("%d points, %d waste, %d animals, %d collisions", match.Score, match.Waste, match.Animals, match.Collisions),
+		)
+		if len(match.Opponents) > 0 {
+			lines = append(lines, "vs "+strings.Join(match.Opponents, ", "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *StatsMenuUI) Draw(screen *ebiten.Image) {
+	s.Ui.Draw(screen)
+}
+
+func (s *StatsMenuUI) Container() *widget.Container {
+	return s.container
+}
+
+func (s *StatsMenuUI) Update() {
+	s.Ui.Update()
+	backButton := s.backButton.GetWidget().Rect
+	mx, my := ebiten.CursorPosition()
+	if backButton.Min.X <= mx && mx <= backButton.Max.X && backButton.Min.Y <= my && my <= backButton.Max.Y {
+		archetype.UpdateCursorImage(true)
+	} else {
+		archetype.UpdateCursorImage(false)
+	}
+}
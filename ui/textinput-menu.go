@@ -25,10 +25,11 @@ type TextInputMenu struct {
 	Change            float32
 	canSubmit         bool
 
-	Value     *string
-	Done      bool
-	Cancel    bool
-	inputText *widget.TextInput
+	Value      *string
+	Done       bool
+	Cancel     bool
+	inputText  *widget.TextInput
+	boatPicker *BoatPicker
 }
 
 func NewTextInputMenu(title string, label string, cancelLabel string, placeHolder string) *TextInputMenu {
@@ -185,8 +186,12 @@ func NewTextInputMenu(title string, label string, cancelLabel string, placeHolde
 	buttonsContainer.AddChild(textInputMenu.okButton)
 	buttonsContainer.AddChild(textInputMenu.cancelButton)
 
+	// players pick their boat together with their name
+	textInputMenu.boatPicker = NewBoatPicker()
+
 	parentContainer.AddChild(welcomeLabelContainer)
 	parentContainer.AddChild(userNameInputContainer)
+	parentContainer.AddChild(textInputMenu.boatPicker.Container())
 	parentContainer.AddChild(buttonsContainer)
 
 	textInputMenu.container.AddChild(parentContainer)
@@ -202,6 +207,13 @@ func NewTextInputMenu(title string, label string, cancelLabel string, placeHolde
 	return textInputMenu
 }
 
+// SetValue fills the input, used to offer the stored name.
+func (s *TextInputMenu) SetValue(value string) {
+	s.inputText.SetText(value)
+	s.inputText.CursorMoveEnd()
+	s.Value = engine.Ptr(value)
+}
+
 func (s *TextInputMenu) Draw(screen *ebiten.Image) {
 	s.Ui.Draw(screen)
 }
@@ -218,7 +230,8 @@ func (s *TextInputMenu) Update() {
 	mx, my := ebiten.CursorPosition()
 	if (okButtonRect.Min.X <= mx && mx <= okButtonRect.Max.X && okButtonRect.Min.Y <= my && my <= okButtonRect.Max.Y) ||
 		(cancelButtonRect.Min.X <= mx && mx <= cancelButtonRect.Max.X && cancelButtonRect.Min.Y <= my && my <= cancelButtonRect.Max.Y) ||
-		(s.pasteButtonWidget != nil && s.pasteButtonWidget.GetWidget().Rect.Min.X <= mx && mx <= s.pasteButtonWidget.GetWidget().Rect.Max.X && s.pasteButtonWidget.GetWidget().Rect.Min.Y <= my && my <= s.pasteButtonWidget.GetWidget().Rect.Max.Y) {
+		(s.pasteButtonWidget != nil && s.pasteButtonWidget.GetWidget().Rect.Min.X <= mx && mx <= s.pasteButtonWidget.GetWidget().Rect.Max.X && s.pasteButtonWidget.GetWidget().Rect.Min.Y <= my && my <= s.pasteButtonWidget.GetWidget().Rect.Max.Y) ||
+		s.boatPicker.Hovered(mx, my) {
 		archetype.UpdateCursorImage(true)
 	} else {
 		archetype.UpdateCursorImage(false)
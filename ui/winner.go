@@ -5,8 +5,10 @@ import (
 	"amaru/assets"
 	"amaru/component"
 	"amaru/engine"
+	"amaru/net"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/ebitenui/ebitenui"
@@ -28,9 +30,19 @@ type WinnerUI struct {
 	textAreaLayoutData widget.RowLayoutData
 	textArea           *widget.TextArea
 	remainingTimeLabel *widget.Label
+	winnerLabel        *widget.Label
+	roundLabel         *widget.Label
+	teamsLabel         *widget.Label
+	teamContainer      *widget.Container
+	teamButton         *widget.Button
+	resultLabels       [][]*widget.Label
 }
 
-func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
+const autoTeamLabel = "Team: Auto"
+
+var resultColumns = []string{"#", "Player", "Team", "Score", "Waste", "Animals", "Penalty", "Acc", "Total"}
+
+func NewWinnerUI(gameData *component.GameData) *WinnerUI {
 	winnerUI := &WinnerUI{
 		container: widget.NewContainer(
 			widget.ContainerOpts.Layout(widget.NewStackedLayout()),
@@ -40,7 +52,8 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 
 	remainingContainer := widget.NewContainer(
 		widget.ContainerOpts.Layout(widget.NewAnchorLayout(widget.AnchorLayoutOpts.Padding(widget.Insets{
-			Top: 5,
+			Top:   5,
+			Right: 10,
 		}))),
 	)
 	winnerUI.remainingTimeLabel = widget.NewLabel(
@@ -54,6 +67,17 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 		VerticalPosition:   widget.AnchorLayoutPositionStart,
 	}
 	remainingContainer.AddChild(winnerUI.remainingTimeLabel)
+	winnerUI.roundLabel = widget.NewLabel(
+		widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+			Disabled: colornames.White,
+			Idle:     colornames.White,
+		}),
+	)
+	winnerUI.roundLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionEnd,
+		VerticalPosition:   widget.AnchorLayoutPositionStart,
+	}
+	remainingContainer.AddChild(winnerUI.roundLabel)
 	winnerUI.container.AddChild(remainingContainer)
 
 	centerContainer := widget.NewContainer(
@@ -64,12 +88,12 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 		widget.ContainerOpts.Layout(widget.NewGridLayout(
 			widget.GridLayoutOpts.Columns(1),
 			widget.GridLayoutOpts.Padding(widget.NewInsetsSimple(20)),
-			widget.GridLayoutOpts.Spacing(25, 5),
+			widget.GridLayoutOpts.Spacing(10, 5),
 		)),
 	)
 	centerContainer.AddChild(parentContainer)
 
-	winnerLabel := widget.NewLabel(widget.LabelOpts.Text(fmt.Sprintf("Winner: %s", winner), assets.MainMidFont, &widget.LabelColor{
+	winnerUI.winnerLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainMidFont, &widget.LabelColor{
 		Disabled: assets.BlueColor,
 		Idle:     assets.BlueColor,
 	}))
@@ -78,11 +102,71 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 		widget.AnchorLayoutOpts.Padding(widget.NewInsetsSimple(0)),
 	)))
 
-	winnerLabelContainer.AddChild(winnerLabel)
-	winnerLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+	winnerLabelContainer.AddChild(winnerUI.winnerLabel)
+	winnerUI.winnerLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
 		HorizontalPosition: widget.AnchorLayoutPositionCenter,
 	}
 
+	winnerUI.teamsLabel = widget.NewLabel(widget.LabelOpts.Text("", assets.MainFont, &widget.LabelColor{
+		Disabled: assets.BlueColor,
+		Idle:     assets.BlueColor,
+	}))
+	teamsLabelContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+	teamsLabelContainer.AddChild(winnerUI.teamsLabel)
+	winnerUI.teamsLabel.GetWidget().LayoutData = widget.AnchorLayoutData{
+		HorizontalPosition: widget.AnchorLayoutPositionCenter,
+	}
+
+	// the team choice shows up once the game data tells the match is in teams
+	winnerUI.teamContainer = widget.NewContainer(widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+	winnerUI.teamButton = widget.NewButton(
+		widget.ButtonOpts.Image(archetype.CreateRoundedButtonImages(160, 40, 5, colornames.White, assets.BlueColor, assets.BlueColor, assets.GreenColor, 5)),
+		widget.ButtonOpts.Text(autoTeamLabel, assets.MainFont, &widget.ButtonTextColor{
+			Idle:     assets.BlueColor,
+			Disabled: assets.BlueColor,
+		}),
+		widget.ButtonOpts.TextPadding(widget.Insets{
+			Top:    5,
+			Bottom: 5,
+			Left:   10,
+			Right:  10,
+		}),
+		widget.ButtonOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				HorizontalPosition: widget.AnchorLayoutPositionCenter,
+			}),
+			widget.WidgetOpts.CursorHovered("buttonHover"),
+			widget.WidgetOpts.CursorPressed("buttonPressed"),
+		),
+		widget.ButtonOpts.ClickedHandler(func(args *widget.ButtonClickedEventArgs) {
+			archetype.PlayButtonClickAudio()
+			winnerUI.chooseNextTeam()
+		}),
+	)
+
+	// one header row and one row per player, filled in by refreshResults
+	resultsContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
+		widget.GridLayoutOpts.Columns(len(resultColumns)),
+		widget.GridLayoutOpts.Spacing(10, 2),
+	)))
+	for row := 0; row <= component.MaxPlayers; row++ {
+		labels := make([]*widget.Label, len(resultColumns))
+		for column := range resultColumns {
+			value := ""
+			if row == 0 {
+				value = resultColumns[column]
+			}
+			labels[column] = widget.NewLabel(widget.LabelOpts.Text(value, assets.MainFont, &widget.LabelColor{
+				Disabled: assets.BlueColor,
+				Idle:     assets.BlueColor,
+			}))
+			resultsContainer.AddChild(labels[column])
+		}
+		if row > 0 {
+			winnerUI.resultLabels = append(winnerUI.resultLabels, labels)
+		}
+	}
+
 	chatContainer := widget.NewContainer(widget.ContainerOpts.Layout(widget.NewGridLayout(
 		widget.GridLayoutOpts.Columns(1),
 		widget.GridLayoutOpts.Spacing(0, 2),
@@ -185,6 +269,9 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 	inputContainer.AddChild(sendButtonContainer)
 
 	parentContainer.AddChild(winnerLabelContainer)
+	parentContainer.AddChild(teamsLabelContainer)
+	parentContainer.AddChild(winnerUI.teamContainer)
+	parentContainer.AddChild(resultsContainer)
 	parentContainer.AddChild(chatContainer)
 
 	winnerUI.container.AddChild(centerContainer)
@@ -198,10 +285,131 @@ func NewWinnerUI(winner string, gameData *component.GameData) *WinnerUI {
 	}
 
 	winnerUI.inputText.Focus(true)
+	winnerUI.refreshResults()
 
 	return winnerUI
 }
 
+// refreshResults fills the results table, peers receive the host game data
+// after the screen is created so it runs on every update.
+func (s *WinnerUI) refreshResults() {
+	gameData := s.Game.Session.RemoteClient.GameData
+	if gameData == nil {
+		return
+	}
+	results := net.RankParticipants(gameData.SessionParticipants)
+	winners := net.Winners(results)
+	if gameData.Rules.Rounds > 0 {
+		s.roundLabel.Label = fmt.Sprintf("Round %d of %d", gameData.Round, gameData.Rules.Rounds)
+	}
+	switch len(winners) {
+	case 0:
+		s.winnerLabel.Label = "No winner"
+	case 1:
+		s.winnerLabel.Label = fmt.Sprintf("Winner: %s", winners[0])
+	default:
+		s.winnerLabel.Label = fmt.Sprintf("Tie: %s", strings.Join(winners, ", "))
+	}
+	s.teamsLabel.Label = ""
+	if gameData.CoopMode() {
+		s.winnerLabel.Label, s.teamsLabel.Label = coopResults(gameData.Coop)
+	}
+	if gameData.TeamMode() {
+		s.winnerLabel.Label, s.teamsLabel.Label = teamResults(net.TeamScores(gameData.SessionParticipants, func(participant *net.SessionParticipant) int {
+			return participant.Score
+		}))
+	}
+	if gameData.TeamMode() && len(s.teamContainer.Children()) == 0 {
+		s.teamContainer.AddChild(s.teamButton)
+	}
+	if local := s.localParticipant(); local != nil {
+		s.teamButton.Text().Label = teamChoiceLabel(local.Choice)
+	}
+	for row, labels := range s.resultLabels {
+		if row >= len(results) {
+			for _, label := range labels {
+				label.Label = ""
+			}
+			continue
+		}
+		participant := results[row].Participant
+		name := net.ParticipantName(participant)
+		if len(name) > 10 {
+			name = name[:10]
+		}
+		values := []string{
+			fmt.Sprintf("%d", results[row].Rank),
+			name,
+			net.TeamNames[participant.Team],
+			fmt.Sprintf("%d", participant.Score),
+			fmt.Sprintf("%d", participant.Round.Waste),
+			fmt.Sprintf("%d", participant.Round.Animals),
+			fmt.Sprintf("-%d", participant.Round.Collisions*gameData.Rules.CollisionPoints),
+			fmt.Sprintf("%.0f%%", participant.Round.Accuracy()*100),
+			fmt.Sprintf("%d", participant.Totals.Score),
+		}
+		for column, label := range labels {
+			label.Label = values[column]
+		}
+	}
+}
+
+func (s *WinnerUI) localParticipant() *net.SessionParticipant {
+	remoteClient := s.Game.Session.RemoteClient
+	if remoteClient.GameData == nil || remoteClient.Client.Id == nil {
+		return nil
+	}
+	return remoteClient.GameData.SessionParticipants[*remoteClient.Client.Id]
+}
+
+// chooseNextTeam cycles the team the local player asks for in the next round,
+// peers ask the host and the host tells everyone with the game data.
+func (s *WinnerUI) chooseNextTeam() {
+	local := s.localParticipant()
+	if local == nil {
+		return
+	}
+	team := (local.Choice + 1) % (len(net.TeamNames) + 1)
+	remoteClient := s.Game.Session.RemoteClient
+	remoteClient.GameData.ChooseTeam(local.Id, team)
+	if s.Game.Session.Type == component.SessionTypeHost {
+		remoteClient.SendGameDataMessage(*remoteClient.GameData)
+	} else {
+		remoteClient.SendTeamChoice(team)
+	}
+}
+
+func teamChoiceLabel(team int) string {
+	if team == net.TeamNone {
+		return autoTeamLabel
+	}
+	return "Team: " + net.TeamNames[team]
+}
+
+// teamResults returns the title naming the winning team and a line with the
+// score of every team.
+func teamResults(scores map[int]int) (string, string) {
+	red, blue := scores[net.TeamRed], scores[net.TeamBlue]
+	totals := fmt.Sprintf("%s %d - %s %d", net.TeamNames[net.TeamRed], red, net.TeamNames[net.TeamBlue], blue)
+	switch {
+	case red > blue:
+		return fmt.Sprintf("Winner: %s team", net.TeamNames[net.TeamRed]), totals
+	case blue > red:
+		return fmt.Sprintf("Winner: %s team", net.TeamNames[net.TeamBlue]), totals
+	}
+	return "Tie", totals
+}
+
+// coopResults returns the title telling if the group reached the goal and a
+// line with the progress of the round.
+func coopResults(progress net.CoopProgress) (string, string) {
+	summary := fmt.Sprintf("Waste %d/%d, animals %d/%d", progress.Waste, progress.WasteGoal(), progress.Animals, progress.AnimalsSize)
+	if progress.Cleared() {
+		return "The ocean is clean!", summary
+	}
+	return "The ocean still needs help", summary
+}
+
 func (s *WinnerUI) UpdateTextArea(text string) {
 	nextText := s.textArea.GetText() + "\n" + text
 	s.textArea.SetText(nextText)
@@ -223,7 +431,7 @@ func (s *WinnerUI) Container() *widget.Container {
 
 func (s *WinnerUI) Update() {
 	if s.MessageDone {
-		go s.Game.Session.RemoteClient.SendChatMessage(*s.MessageValue)
+		s.Game.Session.RemoteClient.SendChatMessage(*s.MessageValue)
 		s.Reset()
 	}
 
@@ -251,6 +459,7 @@ func (s *WinnerUI) Update() {
 	s.inputText.GetWidget().MinWidth = (s.Game.Settings.ScreenHeight / 2) + 32
 
 	textAreaWidget.LayoutData = s.textAreaLayoutData
+	s.refreshResults()
 
 	if s.Game.Session.Type == component.SessionTypeHost && s.Game.Session.RemoteClient.GameData.Counter <= 0 {
 		s.Game.GameOver = true